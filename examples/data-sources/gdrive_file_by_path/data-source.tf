@@ -0,0 +1,10 @@
+# Get a file in a Shared Drive by its path
+data "gdrive_file_by_path" "report" {
+  drive_id = "..."
+  path     = "Finance/Reports/2026/Q3.xlsx"
+}
+
+# Get a folder in the My Drive of the provider's subject
+data "gdrive_file_by_path" "folder" {
+  path = "Projects/Terraform"
+}
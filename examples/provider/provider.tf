@@ -14,3 +14,10 @@ provider "gdrive" {
   service_account_key = "/path/to/sa.json"
   subject             = "admin@example.com"
 }
+
+# Use Workload Identity Federation (e.g., GitHub Actions OIDC) and sign the DWD token with the IAM Credentials API
+provider "gdrive" {
+  external_account_credentials = "/path/to/credential-configuration.json"
+  service_account              = "email@my-project.iam.gserviceaccount.com"
+  subject                      = "admin@example.com"
+}
@@ -13,5 +13,42 @@ resource "gdrive_drive" "drive_restrictions" {
     drive_members_only              = false
     copy_requires_writer_permission = true
     domain_users_only               = true
+
+    sharing_folders_requires_organizer_permission = true
+    download_restricted_for_readers               = true
+  }
+}
+
+# Create a Shared Drive that cannot be destroyed by accident
+resource "gdrive_drive" "drive_protected" {
+  name                    = "terraform-2"
+  use_domain_admin_access = true
+  deletion_protection     = true
+}
+
+# Create a Shared Drive with a department color and hide it from the default view
+resource "gdrive_drive" "drive_archived" {
+  name                    = "terraform-3"
+  use_domain_admin_access = true
+  color_rgb               = "#4986e7"
+  hidden                  = true
+}
+
+# Create a Shared Drive with a custom background image
+resource "gdrive_drive" "drive_background" {
+  name                    = "terraform-4"
+  use_domain_admin_access = true
+  background_image_file {
+    id           = "..."
+    x_coordinate = 0
+    y_coordinate = 0
+    width        = 1
   }
 }
+
+# Create a Shared Drive for an ephemeral environment that is deleted with all of its content
+resource "gdrive_drive" "drive_ephemeral" {
+  name                    = "terraform-5"
+  use_domain_admin_access = true
+  force_destroy           = true
+}
@@ -21,3 +21,51 @@ resource "gdrive_file" "import_csv" {
   content          = "./test.csv"
   parent           = gdrive_file.folder.id
 }
+
+# Create a folder in the personal Drive of each user
+resource "gdrive_file" "user_folders" {
+  for_each            = toset(["alice@example.com", "bob@example.com"])
+  impersonate_subject = each.key
+  mime_type           = "application/vnd.google-apps.folder"
+  parent              = "root"
+  name                = "Projects"
+}
+
+# Upload a rendered template without writing it to disk first
+resource "gdrive_file" "config" {
+  name           = "config.yaml"
+  mime_type      = "text/plain"
+  parent         = gdrive_file.folder.id
+  content_string = templatefile("${path.module}/config.yaml.tftpl", { environment = "prod" })
+}
+
+# Tag a folder with app properties
+resource "gdrive_file" "team_folder" {
+  mime_type        = "application/vnd.google-apps.folder"
+  parent           = gdrive_file.folder.id
+  name             = "Team"
+  description      = "Managed by Terraform"
+  folder_color_rgb = "#4986e7"
+  app_properties = {
+    team        = "platform"
+    cost_center = "1234"
+  }
+}
+
+# Protect a folder tree from being destroyed and move it to the trash once protection is lifted
+resource "gdrive_file" "archive" {
+  mime_type           = "application/vnd.google-apps.folder"
+  parent              = gdrive_file.folder.id
+  name                = "Archive"
+  delete_behavior     = "trash"
+  deletion_protection = true
+}
+
+# Upload a large archive in chunks of 64 MiB
+resource "gdrive_file" "dataset" {
+  mime_type  = "application/gzip"
+  name       = "dataset.tar.gz"
+  parent     = gdrive_file.folder.id
+  content    = "/path/to/dataset.tar.gz"
+  chunk_size = 67108864
+}
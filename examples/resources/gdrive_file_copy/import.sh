@@ -0,0 +1,2 @@
+# the source_id attribute must be specified during the import.
+terraform import gdrive_file_copy.copy [source_id],[file_id]
@@ -0,0 +1,6 @@
+# Copy the standard project templates into the Shared Drive of a new project
+resource "gdrive_file_copy" "project_plan" {
+  source_id = "1AbCdEfGhIjKlMnOpQrStUvWxYz" # file_id of the template
+  parent    = gdrive_drive.project.drive_id
+  name      = "Project Plan"
+}
@@ -0,0 +1,2 @@
+# the source_dir attribute must be specified during the import.
+terraform import gdrive_folder_sync.reports [folder_id],[source_dir]
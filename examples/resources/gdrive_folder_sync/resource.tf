@@ -0,0 +1,8 @@
+# Publish the rendered reports to a folder and remove reports that no longer exist locally.
+# Destroying the resource removes the synced files and folders, including anything that was added to them by hand,
+# so deletion_protection (true by default) must be set to false and applied first.
+resource "gdrive_folder_sync" "reports" {
+  folder_id      = gdrive_file.reports.file_id
+  source_dir     = "${path.module}/reports"
+  delete_orphans = true
+}
@@ -0,0 +1 @@
+terraform import gdrive_shortcut.shortcut [shortcut_id]
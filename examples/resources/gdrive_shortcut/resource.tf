@@ -0,0 +1,6 @@
+# Link a folder of another Shared Drive into the team's Shared Drive
+resource "gdrive_shortcut" "shortcut" {
+  name      = "Templates"
+  parent    = gdrive_drive.team.drive_id
+  target_id = gdrive_file.templates.file_id
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	cibeta "google.golang.org/api/cloudidentity/v1beta1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+	"google.golang.org/api/option"
+)
+
+// gdriveClient holds the API services of a single provider instance.
+// It is passed to every resource and data source via ProviderData, so that
+// aliased provider blocks never share credentials.
+type gdriveClient struct {
+	drive         *drive.Service
+	driveLabels   *drivelabels.Service
+	cloudIdentity *cibeta.Service
+	retryPolicy   *retryPolicy
+	// subject is the user this client impersonates.
+	subject string
+	// subjects is shared by all clients of a provider instance.
+	subjects *subjectClients
+}
+
+// subjectClients caches the clients for users that are impersonated with impersonate_subject.
+type subjectClients struct {
+	mu      sync.Mutex
+	auth    *gdriveAuthConfig
+	config  *gdriveClientConfig
+	clients map[string]*gdriveClient
+}
+
+// gdriveClientConfig holds the provider settings that are used to build a gdriveClient.
+type gdriveClientConfig struct {
+	driveEndpoint         string
+	driveLabelsEndpoint   string
+	cloudIdentityEndpoint string
+	retryPolicy           *retryPolicy
+	// readLimit and writeLimit are shared by the clients of all impersonated subjects,
+	// because max_qps_read and max_qps_write apply to the provider instance as a whole.
+	readLimit  *tokenBucket
+	writeLimit *tokenBucket
+	readOnly   bool
+}
+
+// serviceOptions returns the options for one of the API services.
+// If endpoint is empty, the default Google endpoint of the service is used.
+func serviceOptions(httpClient *http.Client, endpoint string) []option.ClientOption {
+	opts := []option.ClientOption{
+		option.WithHTTPClient(httpClient),
+	}
+	if endpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+	return opts
+}
+
+// newClient returns a client that impersonates subject.
+func newClient(ctx context.Context, auth *gdriveAuthConfig, subject string, config *gdriveClientConfig) (*gdriveClient, error) {
+	httpClient, err := auth.httpClient(subject)
+	if err != nil {
+		return nil, err
+	}
+	httpClient = withTransports(httpClient, config)
+	driveService, err := drive.NewService(ctx, serviceOptions(httpClient, config.driveEndpoint)...)
+	if err != nil {
+		return nil, err
+	}
+	driveLabelsService, err := drivelabels.NewService(ctx, serviceOptions(httpClient, config.driveLabelsEndpoint)...)
+	if err != nil {
+		return nil, err
+	}
+	cloudIdentityService, err := cibeta.NewService(ctx, serviceOptions(httpClient, config.cloudIdentityEndpoint)...)
+	if err != nil {
+		return nil, err
+	}
+	return &gdriveClient{
+		drive:         driveService,
+		driveLabels:   driveLabelsService,
+		cloudIdentity: cloudIdentityService,
+		retryPolicy:   config.retryPolicy,
+		subject:       subject,
+		subjects: &subjectClients{
+			auth:    auth,
+			config:  config,
+			clients: map[string]*gdriveClient{},
+		},
+	}, nil
+}
+
+// forSubject returns a client that impersonates subject.
+// The client is created on first use and shared by all resources of the provider instance afterwards.
+// If subject is empty, c is returned.
+func (c *gdriveClient) forSubject(ctx context.Context, subject string) (*gdriveClient, error) {
+	if subject == "" || subject == c.subject {
+		return c, nil
+	}
+	s := c.subjects
+	if s.auth.accessToken != "" && s.auth.serviceAccount == "" {
+		return nil, fmt.Errorf("impersonating %s requires Domain Wide Delegation, which is not possible with an access token unless service_account is set", subject)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client, ok := s.clients[subject]
+	if ok {
+		return client, nil
+	}
+	client, err := newClient(ctx, s.auth, subject, s.config)
+	if err != nil {
+		return nil, err
+	}
+	client.subjects = s
+	s.clients[subject] = client
+	return client, nil
+}
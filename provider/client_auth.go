@@ -0,0 +1,193 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hanneshayashi/gsm/gsmauth"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+)
+
+// gdriveAuthConfig holds the credentials of a provider instance.
+// It is used to build HTTP clients that impersonate a Workspace user via Domain Wide Delegation (DWD).
+type gdriveAuthConfig struct {
+	serviceAccountKey         []byte
+	serviceAccount            string
+	externalAccountCredential []byte
+	accessToken               string
+	scopes                    []string
+}
+
+// readCredentials returns s, if it is a JSON document, or the content of the file at path s.
+func readCredentials(s string) ([]byte, error) {
+	b := []byte(s)
+	if json.Valid(b) {
+		return b, nil
+	}
+	return os.ReadFile(s)
+}
+
+// httpClient returns an HTTP client that acts as subject.
+// If an access token is configured without a Service Account, the token is used as-is and subject is ignored.
+func (a *gdriveAuthConfig) httpClient(subject string) (*http.Client, error) {
+	// The token sources outlive the Configure call, so they must not use its context.
+	ctx := context.Background()
+	switch {
+	case a.accessToken != "" && a.serviceAccount == "":
+		return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: a.accessToken})), nil
+	case a.accessToken != "":
+		base := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: a.accessToken})
+		return a.dwdClient(ctx, base, subject)
+	case a.externalAccountCredential != nil:
+		base, err := externalAccountTokenSource(ctx, a.externalAccountCredential)
+		if err != nil {
+			return nil, err
+		}
+		return a.dwdClient(ctx, base, subject)
+	case a.serviceAccountKey != nil:
+		return gsmauth.GetClient(subject, a.serviceAccountKey, a.scopes...)
+	default:
+		return gsmauth.GetClientADC(subject, a.serviceAccount, a.scopes...)
+	}
+}
+
+// externalAccountTokenSource returns a token source for an external account (Workload Identity Federation) credential configuration,
+// such as the ones used for GitHub Actions OIDC tokens or AWS.
+func externalAccountTokenSource(ctx context.Context, credentialConfig []byte) (oauth2.TokenSource, error) {
+	var f struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(credentialConfig, &f)
+	if err != nil {
+		return nil, err
+	}
+	if f.Type != "external_account" {
+		return nil, fmt.Errorf("expected credential configuration of type \"external_account\", got %q", f.Type)
+	}
+	creds, err := google.CredentialsFromJSON(ctx, credentialConfig, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return nil, err
+	}
+	return creds.TokenSource, nil
+}
+
+// dwdClient returns an HTTP client that impersonates subject by having the IAM Credentials API
+// sign a JWT for the configured Service Account with the base credentials.
+func (a *gdriveAuthConfig) dwdClient(ctx context.Context, base oauth2.TokenSource, subject string) (*http.Client, error) {
+	if a.serviceAccount == "" {
+		return nil, fmt.Errorf("service_account must be set to use Domain Wide Delegation with external account credentials or an access token")
+	}
+	iamCredentialsService, err := iamcredentials.NewService(ctx, option.WithTokenSource(base))
+	if err != nil {
+		return nil, err
+	}
+	ts := &dwdTokenSource{
+		ctx:            ctx,
+		iamCredentials: iamCredentialsService,
+		serviceAccount: a.serviceAccount,
+		subject:        subject,
+		scopes:         a.scopes,
+	}
+	return oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, ts)), nil
+}
+
+// dwdTokenSource creates access tokens for subject using the signJwt method of the IAM Credentials API.
+// This allows Domain Wide Delegation without a Service Account Key.
+// oauth2.TokenSource has no context parameter, so every call to Token is bounded by tokenRequestTimeout.
+type dwdTokenSource struct {
+	ctx            context.Context
+	iamCredentials *iamcredentials.Service
+	serviceAccount string
+	subject        string
+	scopes         []string
+}
+
+// tokenRequestTimeout is the maximum time dwdTokenSource spends on signing a JWT and exchanging it for an access token.
+const tokenRequestTimeout = time.Minute
+
+func (ts *dwdTokenSource) Token() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(ts.ctx, tokenRequestTimeout)
+	defer cancel()
+	now := time.Now()
+	claims, err := json.Marshal(map[string]any{
+		"iss":   ts.serviceAccount,
+		"sub":   ts.subject,
+		"scope": strings.Join(ts.scopes, " "),
+		"aud":   google.Endpoint.TokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	signed, err := ts.iamCredentials.Projects.ServiceAccounts.SignJwt("projects/-/serviceAccounts/"+ts.serviceAccount, &iamcredentials.SignJwtRequest{
+		Payload: string(claims),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign JWT for %s: %w", ts.serviceAccount, err)
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signed.SignedJwt},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, google.Endpoint.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The token exchange bypasses the provider's transports, so that the access token in the response
+	// is never logged and token requests do not count against max_qps_write.
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to exchange JWT for an access token for %s: %s: %s", ts.subject, resp.Status, body)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      now.Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+
+	cibeta "google.golang.org/api/cloudidentity/v1beta1"
+	"google.golang.org/api/googleapi"
+)
+
+func (c *gdriveClient) listOrgUnitMemberships(ctx context.Context, parent, customer, filter, fields string) ([]*cibeta.OrgMembership, error) {
+	call := c.cloudIdentity.OrgUnits.Memberships.List(parent).Customer(customer)
+	if filter != "" {
+		call.Filter(filter)
+	}
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	memberships := []*cibeta.OrgMembership{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		memberships = memberships[:0]
+		return nil, call.Pages(ctx, func(r *cibeta.ListOrgMembershipsResponse) error {
+			memberships = append(memberships, r.OrgMemberships...)
+			return nil
+		})
+	})
+	return memberships, err
+}
+
+func (c *gdriveClient) moveOrgUnitMemberships(ctx context.Context, name, fields string, req *cibeta.MoveOrgMembershipRequest) (*cibeta.Operation, error) {
+	call := c.cloudIdentity.OrgUnits.Memberships.Move(name, req)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	return retry(ctx, c, func(ctx context.Context) (*cibeta.Operation, error) {
+		return call.Context(ctx).Do()
+	})
+}
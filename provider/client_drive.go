@@ -0,0 +1,354 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// rewind resets content to its beginning, so that an upload can be retried.
+func rewind(content io.Reader) error {
+	if seeker, ok := content.(io.Seeker); ok {
+		_, err := seeker.Seek(0, io.SeekStart)
+		return err
+	}
+	return nil
+}
+
+// contentSize returns the size of content or 0 if it is unknown.
+func contentSize(content io.Reader) int64 {
+	seeker, ok := content.(io.Seeker)
+	if !ok {
+		return 0
+	}
+	size, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0
+	}
+	_, err = seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// mediaOptions returns the options for uploading content.
+// Content that is larger than chunkSize is uploaded in chunks in a resumable upload session,
+// which resumes after retryable errors until the max_elapsed_time of the retry policy has passed.
+// If chunkSize is 0, the content is uploaded in a single request.
+func (c *gdriveClient) mediaOptions(contentType string, chunkSize int64) []googleapi.MediaOption {
+	opts := []googleapi.MediaOption{
+		googleapi.ChunkSize(int(chunkSize)),
+		googleapi.ChunkRetryDeadline(c.retryPolicy.maxElapsedTime),
+	}
+	if contentType != "" {
+		opts = append(opts, googleapi.ContentType(contentType))
+	}
+	return opts
+}
+
+// uploadProgress returns a ProgressUpdater that logs the progress of an upload of size bytes.
+func uploadProgress(ctx context.Context, fileName string, size int64) googleapi.ProgressUpdater {
+	return func(current, total int64) {
+		if total == 0 {
+			total = size
+		}
+		tflog.Info(ctx, "Uploading content", map[string]interface{}{
+			"name":           fileName,
+			"uploaded_bytes": current,
+			"total_bytes":    total,
+		})
+	}
+}
+
+func (c *gdriveClient) getFile(ctx context.Context, fileID, fields string) (*drive.File, error) {
+	call := c.drive.Files.Get(fileID).SupportsAllDrives(true).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.File, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) listFiles(ctx context.Context, q, driveID, corpora, spaces, fields string, includeItemsFromAllDrives bool) ([]*drive.File, error) {
+	call := c.drive.Files.List().SupportsAllDrives(true).IncludeItemsFromAllDrives(includeItemsFromAllDrives).Fields(googleapi.Field(fields))
+	if q != "" {
+		call.Q(q)
+	}
+	if driveID != "" {
+		call.DriveId(driveID)
+	}
+	if corpora != "" {
+		call.Corpora(corpora)
+	}
+	if spaces != "" {
+		call.Spaces(spaces)
+	}
+	files := []*drive.File{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		files = files[:0]
+		return nil, call.Pages(ctx, func(r *drive.FileList) error {
+			files = append(files, r.Files...)
+			return nil
+		})
+	})
+	return files, err
+}
+
+func (c *gdriveClient) createFile(ctx context.Context, file *drive.File, content io.Reader, mimeTypeSource, fields string, chunkSize int64) (*drive.File, error) {
+	call := c.drive.Files.Create(file).SupportsAllDrives(true).Fields(googleapi.Field(fields))
+	size := contentSize(content)
+	return retry(ctx, c, func(ctx context.Context) (*drive.File, error) {
+		if content != nil {
+			err := rewind(content)
+			if err != nil {
+				return nil, err
+			}
+			call.Media(content, c.mediaOptions(mimeTypeSource, chunkSize)...)
+			call.ProgressUpdater(uploadProgress(ctx, file.Name, size))
+		}
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) updateFile(ctx context.Context, fileID, addParents, removeParents, fields string, file *drive.File, content io.Reader, chunkSize int64) (*drive.File, error) {
+	call := c.drive.Files.Update(fileID, file).SupportsAllDrives(true).Fields(googleapi.Field(fields))
+	if addParents != "" {
+		call.AddParents(addParents)
+	}
+	if removeParents != "" {
+		call.RemoveParents(removeParents)
+	}
+	size := contentSize(content)
+	return retry(ctx, c, func(ctx context.Context) (*drive.File, error) {
+		if content != nil {
+			err := rewind(content)
+			if err != nil {
+				return nil, err
+			}
+			call.Media(content, c.mediaOptions("", chunkSize)...)
+			call.ProgressUpdater(uploadProgress(ctx, file.Name, size))
+		}
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) copyFile(ctx context.Context, fileID, fields string, ignoreDefaultVisibility, keepRevisionForever bool, file *drive.File) (*drive.File, error) {
+	call := c.drive.Files.Copy(fileID, file).SupportsAllDrives(true).IgnoreDefaultVisibility(ignoreDefaultVisibility).KeepRevisionForever(keepRevisionForever).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.File, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+// trashFile moves a file to the trash. Trashed folders are restored with all of their descendants.
+func (c *gdriveClient) trashFile(ctx context.Context, fileID string) error {
+	call := c.drive.Files.Update(fileID, &drive.File{Trashed: true}).SupportsAllDrives(true).Fields("id")
+	_, err := retry(ctx, c, func(ctx context.Context) (*drive.File, error) {
+		return call.Context(ctx).Do()
+	})
+	return err
+}
+
+func (c *gdriveClient) deleteFile(ctx context.Context, fileID string) error {
+	call := c.drive.Files.Delete(fileID).SupportsAllDrives(true)
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		return nil, call.Context(ctx).Do()
+	})
+	return err
+}
+
+// writeResponse writes the body of a download or export response to localFilePath.
+func writeResponse(r io.ReadCloser, localFilePath string) (string, error) {
+	defer r.Close()
+	f, err := os.Create(localFilePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	if err != nil {
+		return "", err
+	}
+	return localFilePath, nil
+}
+
+func (c *gdriveClient) downloadFile(ctx context.Context, fileID, localFilePath string) (string, error) {
+	call := c.drive.Files.Get(fileID).SupportsAllDrives(true)
+	r, err := retry(ctx, c, func(ctx context.Context) (io.ReadCloser, error) {
+		resp, err := call.Context(ctx).Download()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return writeResponse(r, localFilePath)
+}
+
+func (c *gdriveClient) exportFile(ctx context.Context, fileID, mimeType, localFilePath string) (string, error) {
+	call := c.drive.Files.Export(fileID, mimeType)
+	r, err := retry(ctx, c, func(ctx context.Context) (io.ReadCloser, error) {
+		resp, err := call.Context(ctx).Download()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return writeResponse(r, localFilePath)
+}
+
+func (c *gdriveClient) listLabels(ctx context.Context, fileID, fields string) ([]*drive.Label, error) {
+	call := c.drive.Files.ListLabels(fileID)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	labels := []*drive.Label{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		labels = labels[:0]
+		return nil, call.Pages(ctx, func(r *drive.LabelList) error {
+			labels = append(labels, r.Labels...)
+			return nil
+		})
+	})
+	return labels, err
+}
+
+func (c *gdriveClient) modifyLabels(ctx context.Context, fileID, fields string, req *drive.ModifyLabelsRequest) (*drive.ModifyLabelsResponse, error) {
+	call := c.drive.Files.ModifyLabels(fileID, req)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drive.ModifyLabelsResponse, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) getDrive(ctx context.Context, driveID, fields string, useDomainAdminAccess bool) (*drive.Drive, error) {
+	call := c.drive.Drives.Get(driveID).UseDomainAdminAccess(useDomainAdminAccess).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Drive, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) listDrives(ctx context.Context, q, fields string, useDomainAdminAccess bool) ([]*drive.Drive, error) {
+	call := c.drive.Drives.List().UseDomainAdminAccess(useDomainAdminAccess).Fields(googleapi.Field(fields))
+	if q != "" {
+		call.Q(q)
+	}
+	drives := []*drive.Drive{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		drives = drives[:0]
+		return nil, call.Pages(ctx, func(r *drive.DriveList) error {
+			drives = append(drives, r.Drives...)
+			return nil
+		})
+	})
+	return drives, err
+}
+
+// createDrive creates a Shared Drive.
+// Requests with the same requestID create at most one Shared Drive. Repeated requests fail with 409.
+func (c *gdriveClient) createDrive(ctx context.Context, requestID string, d *drive.Drive, fields string) (*drive.Drive, error) {
+	call := c.drive.Drives.Create(requestID, d).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Drive, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) updateDrive(ctx context.Context, driveID, fields string, useDomainAdminAccess bool, d *drive.Drive) (*drive.Drive, error) {
+	call := c.drive.Drives.Update(driveID, d).UseDomainAdminAccess(useDomainAdminAccess).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Drive, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) hideDrive(ctx context.Context, driveID, fields string) (*drive.Drive, error) {
+	call := c.drive.Drives.Hide(driveID).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Drive, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) unhideDrive(ctx context.Context, driveID, fields string) (*drive.Drive, error) {
+	call := c.drive.Drives.Unhide(driveID).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Drive, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) deleteDrive(ctx context.Context, driveID string, useDomainAdminAccess, allowItemDeletion bool) error {
+	call := c.drive.Drives.Delete(driveID).UseDomainAdminAccess(useDomainAdminAccess).AllowItemDeletion(allowItemDeletion)
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		return nil, call.Context(ctx).Do()
+	})
+	return err
+}
+
+func (c *gdriveClient) getPermission(ctx context.Context, fileID, permissionID, fields string, useDomainAdminAccess bool) (*drive.Permission, error) {
+	call := c.drive.Permissions.Get(fileID, permissionID).SupportsAllDrives(true).UseDomainAdminAccess(useDomainAdminAccess).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Permission, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) listPermissions(ctx context.Context, fileID, fields string, useDomainAdminAccess bool) ([]*drive.Permission, error) {
+	call := c.drive.Permissions.List(fileID).SupportsAllDrives(true).UseDomainAdminAccess(useDomainAdminAccess).Fields(googleapi.Field(fields))
+	permissions := []*drive.Permission{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		permissions = permissions[:0]
+		return nil, call.Pages(ctx, func(r *drive.PermissionList) error {
+			permissions = append(permissions, r.Permissions...)
+			return nil
+		})
+	})
+	return permissions, err
+}
+
+func (c *gdriveClient) createPermission(ctx context.Context, fileID, emailMessage, fields string, useDomainAdminAccess, sendNotificationEmail, transferOwnership, moveToNewOwnersRoot bool, permission *drive.Permission) (*drive.Permission, error) {
+	call := c.drive.Permissions.Create(fileID, permission).SupportsAllDrives(true).UseDomainAdminAccess(useDomainAdminAccess).SendNotificationEmail(sendNotificationEmail).TransferOwnership(transferOwnership).MoveToNewOwnersRoot(moveToNewOwnersRoot).Fields(googleapi.Field(fields))
+	if emailMessage != "" {
+		call.EmailMessage(emailMessage)
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drive.Permission, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) updatePermission(ctx context.Context, fileID, permissionID, fields string, useDomainAdminAccess, removeExpiration bool, permission *drive.Permission) (*drive.Permission, error) {
+	call := c.drive.Permissions.Update(fileID, permissionID, permission).SupportsAllDrives(true).UseDomainAdminAccess(useDomainAdminAccess).RemoveExpiration(removeExpiration).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drive.Permission, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) deletePermission(ctx context.Context, fileID, permissionID string, useDomainAdminAccess bool) error {
+	call := c.drive.Permissions.Delete(fileID, permissionID).SupportsAllDrives(true).UseDomainAdminAccess(useDomainAdminAccess)
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		return nil, call.Context(ctx).Do()
+	})
+	return err
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"google.golang.org/api/drivelabels/v2"
+	"google.golang.org/api/googleapi"
+)
+
+func (c *gdriveClient) getLabel(ctx context.Context, name, languageCode, view, fields string, useAdminAccess bool) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.Get(name).UseAdminAccess(useAdminAccess).Fields(googleapi.Field(fields))
+	if languageCode != "" {
+		call.LanguageCode(languageCode)
+	}
+	if view != "" {
+		call.View(view)
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) listDriveLabels(ctx context.Context, languageCode, view, minimumRole, fields string, useAdminAccess, publishedOnly bool) ([]*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.List().UseAdminAccess(useAdminAccess).PublishedOnly(publishedOnly).Fields(googleapi.Field(fields))
+	if languageCode != "" {
+		call.LanguageCode(languageCode)
+	}
+	if view != "" {
+		call.View(view)
+	}
+	if minimumRole != "" {
+		call.MinimumRole(minimumRole)
+	}
+	labels := []*drivelabels.GoogleAppsDriveLabelsV2Label{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		labels = labels[:0]
+		return nil, call.Pages(ctx, func(r *drivelabels.GoogleAppsDriveLabelsV2ListLabelsResponse) error {
+			labels = append(labels, r.Labels...)
+			return nil
+		})
+	})
+	return labels, err
+}
+
+func (c *gdriveClient) createLabel(ctx context.Context, label *drivelabels.GoogleAppsDriveLabelsV2Label, languageCode, fields string, useAdminAccess bool) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.Create(label).UseAdminAccess(useAdminAccess).Fields(googleapi.Field(fields))
+	if languageCode != "" {
+		call.LanguageCode(languageCode)
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) deleteLabel(ctx context.Context, name, requiredRevisionID string, useAdminAccess bool) error {
+	call := c.driveLabels.Labels.Delete(name).UseAdminAccess(useAdminAccess)
+	if requiredRevisionID != "" {
+		call.WriteControlRequiredRevisionId(requiredRevisionID)
+	}
+	_, err := retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleProtobufEmpty, error) {
+		return call.Context(ctx).Do()
+	})
+	return err
+}
+
+func (c *gdriveClient) deltaLabel(ctx context.Context, name, fields string, req *drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelRequest) (*drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelResponse, error) {
+	call := c.driveLabels.Labels.Delta(name, req).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelResponse, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) publishLabel(ctx context.Context, name, fields string, req *drivelabels.GoogleAppsDriveLabelsV2PublishLabelRequest) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.Publish(name, req).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) disableLabel(ctx context.Context, name, fields string, req *drivelabels.GoogleAppsDriveLabelsV2DisableLabelRequest) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.Disable(name, req).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) enableLabel(ctx context.Context, name, fields string, req *drivelabels.GoogleAppsDriveLabelsV2EnableLabelRequest) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+	call := c.driveLabels.Labels.Enable(name, req).Fields(googleapi.Field(fields))
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2Label, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) createLabelPermission(ctx context.Context, parent, fields string, useAdminAccess bool, permission *drivelabels.GoogleAppsDriveLabelsV2LabelPermission) (*drivelabels.GoogleAppsDriveLabelsV2LabelPermission, error) {
+	call := c.driveLabels.Labels.Permissions.Create(parent, permission).UseAdminAccess(useAdminAccess)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2LabelPermission, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) listLabelPermissions(ctx context.Context, parent, fields string, useAdminAccess bool) ([]*drivelabels.GoogleAppsDriveLabelsV2LabelPermission, error) {
+	call := c.driveLabels.Labels.Permissions.List(parent).UseAdminAccess(useAdminAccess)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	permissions := []*drivelabels.GoogleAppsDriveLabelsV2LabelPermission{}
+	_, err := retry(ctx, c, func(ctx context.Context) (any, error) {
+		permissions = permissions[:0]
+		return nil, call.Pages(ctx, func(r *drivelabels.GoogleAppsDriveLabelsV2ListLabelPermissionsResponse) error {
+			permissions = append(permissions, r.LabelPermissions...)
+			return nil
+		})
+	})
+	return permissions, err
+}
+
+func (c *gdriveClient) batchUpdateLabelPermissions(ctx context.Context, parent, fields string, req *drivelabels.GoogleAppsDriveLabelsV2BatchUpdateLabelPermissionsRequest) (*drivelabels.GoogleAppsDriveLabelsV2BatchUpdateLabelPermissionsResponse, error) {
+	call := c.driveLabels.Labels.Permissions.BatchUpdate(parent, req)
+	if fields != "" {
+		call.Fields(googleapi.Field(fields))
+	}
+	return retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleAppsDriveLabelsV2BatchUpdateLabelPermissionsResponse, error) {
+		return call.Context(ctx).Do()
+	})
+}
+
+func (c *gdriveClient) batchDeleteLabelPermissions(ctx context.Context, parent string, req *drivelabels.GoogleAppsDriveLabelsV2BatchDeleteLabelPermissionsRequest) error {
+	call := c.driveLabels.Labels.Permissions.BatchDelete(parent, req)
+	_, err := retry(ctx, c, func(ctx context.Context) (*drivelabels.GoogleProtobufEmpty, error) {
+		return call.Context(ctx).Do()
+	})
+	return err
+}
@@ -0,0 +1,199 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	retryDefaultInitialInterval = 500 * time.Millisecond
+	retryDefaultMaxInterval     = 60 * time.Second
+	retryDefaultMaxElapsedTime  = 3 * time.Minute
+	retryDefaultMultiplier      = 1.5
+	retryDefaultJitter          = 0.5
+)
+
+// retryPolicy defines the exponential backoff strategy of a provider instance.
+type retryPolicy struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	maxElapsedTime  time.Duration
+	multiplier      float64
+	jitter          float64
+	// statusCodes maps the HTTP status codes that should be retried to the maximum elapsed time for that code.
+	statusCodes map[int]time.Duration
+}
+
+// newRetryPolicy returns the default retry policy that doesn't retry on any status code.
+func newRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		initialInterval: retryDefaultInitialInterval,
+		maxInterval:     retryDefaultMaxInterval,
+		maxElapsedTime:  retryDefaultMaxElapsedTime,
+		multiplier:      retryDefaultMultiplier,
+		jitter:          retryDefaultJitter,
+		statusCodes:     map[int]time.Duration{},
+	}
+}
+
+// errNotFound is returned (wrapped) by helpers, when an object could not be found in an API response.
+var errNotFound = errors.New("not found")
+
+// isNotFound returns true if err indicates that the requested object does not exist (anymore).
+func isNotFound(err error) bool {
+	if errors.Is(err, errNotFound) {
+		return true
+	}
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr) && gErr.Code == http.StatusNotFound
+}
+
+// isConflict returns true if err indicates that the object already exists.
+func isConflict(err error) bool {
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr) && gErr.Code == http.StatusConflict
+}
+
+// isAPIError returns true if err is an error response of a Google API.
+// Other errors, e.g. connection resets, leave it unknown whether the request was processed.
+func isAPIError(err error) bool {
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr)
+}
+
+// withoutRetryOn returns a copy of c that does not retry on the given status codes.
+// Reads use this to detect deleted objects without waiting for the retry budget to run out.
+func (c *gdriveClient) withoutRetryOn(statusCodes ...int) *gdriveClient {
+	policy := *c.retryPolicy
+	policy.statusCodes = maps.Clone(c.retryPolicy.statusCodes)
+	for i := range statusCodes {
+		delete(policy.statusCodes, statusCodes[i])
+	}
+	client := *c
+	client.retryPolicy = &policy
+	return &client
+}
+
+// withRetryOn returns a copy of c that also retries on the given status codes, using the maximum elapsed time of the policy.
+// Codes that are already retried keep their configured maximum elapsed time.
+func (c *gdriveClient) withRetryOn(statusCodes ...int) *gdriveClient {
+	policy := *c.retryPolicy
+	policy.statusCodes = maps.Clone(c.retryPolicy.statusCodes)
+	for i := range statusCodes {
+		if _, ok := policy.statusCodes[statusCodes[i]]; !ok {
+			policy.statusCodes[statusCodes[i]] = policy.maxElapsedTime
+		}
+	}
+	client := *c
+	client.retryPolicy = &policy
+	return &client
+}
+
+// isRateLimitError returns true if the error indicates a rate limiting / quota issue.
+func isRateLimitError(gErr *googleapi.Error) bool {
+	if gErr.Code != http.StatusForbidden && gErr.Code != http.StatusTooManyRequests {
+		return false
+	}
+	for i := range gErr.Errors {
+		switch gErr.Errors[i].Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded":
+			return true
+		}
+	}
+	return false
+}
+
+// maxElapsedTimeFor returns the maximum elapsed time for retrying err or false,
+// if err should not be retried at all.
+// Errors that indicate a rate limiting / quota issue are always retried.
+func (p *retryPolicy) maxElapsedTimeFor(err error) (time.Duration, bool) {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return 0, false
+	}
+	maxElapsedTime, ok := p.statusCodes[gErr.Code]
+	if ok {
+		return maxElapsedTime, true
+	}
+	if isRateLimitError(gErr) {
+		return p.maxElapsedTime, true
+	}
+	return 0, false
+}
+
+// nextInterval returns a randomized wait time based on interval and the configured jitter.
+func (p *retryPolicy) nextInterval(interval time.Duration) time.Duration {
+	delta := p.jitter * float64(interval)
+	return time.Duration(float64(interval) - delta + rand.Float64()*(2*delta+1))
+}
+
+type retryAttemptKey struct{}
+
+// retryAttempt returns the attempt number (starting at 1) that is stored in ctx by retry.
+func retryAttempt(ctx context.Context) int {
+	attempt, ok := ctx.Value(retryAttemptKey{}).(int)
+	if !ok {
+		return 1
+	}
+	return attempt
+}
+
+// retry calls f until it succeeds, returns an error that should not be retried,
+// the maximum elapsed time is reached or ctx is done.
+// The context passed to f carries the current attempt number, so that it can be logged by the transport.
+func retry[T any](ctx context.Context, c *gdriveClient, f func(ctx context.Context) (T, error)) (T, error) {
+	p := c.retryPolicy
+	start := time.Now()
+	interval := p.initialInterval
+	for attempt := 1; ; attempt++ {
+		r, err := f(context.WithValue(ctx, retryAttemptKey{}, attempt))
+		if err == nil {
+			return r, nil
+		}
+		maxElapsedTime, ok := p.maxElapsedTimeFor(err)
+		if !ok {
+			return r, err
+		}
+		wait := p.nextInterval(interval)
+		if time.Since(start)+wait > maxElapsedTime {
+			return r, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return r, err
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return r, err
+		case <-t.C:
+		}
+		interval = time.Duration(float64(interval) * p.multiplier)
+		if interval > p.maxInterval {
+			interval = p.maxInterval
+		}
+	}
+}
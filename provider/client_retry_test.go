@@ -0,0 +1,187 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func apiError(code int, reasons ...string) error {
+	gErr := &googleapi.Error{Code: code}
+	for i := range reasons {
+		gErr.Errors = append(gErr.Errors, googleapi.ErrorItem{Reason: reasons[i]})
+	}
+	return gErr
+}
+
+// testRetryPolicy returns a policy with short, deterministic intervals.
+func testRetryPolicy(statusCodes map[int]time.Duration) *retryPolicy {
+	return &retryPolicy{
+		initialInterval: time.Millisecond,
+		maxInterval:     2 * time.Millisecond,
+		maxElapsedTime:  time.Minute,
+		multiplier:      2,
+		statusCodes:     statusCodes,
+	}
+}
+
+func TestRetryPolicyMaxElapsedTimeFor(t *testing.T) {
+	policy := testRetryPolicy(map[int]time.Duration{
+		http.StatusServiceUnavailable: 5 * time.Minute,
+		http.StatusForbidden:          10 * time.Second,
+	})
+	tests := []struct {
+		name           string
+		err            error
+		maxElapsedTime time.Duration
+		retry          bool
+	}{
+		{"configured status code", apiError(http.StatusServiceUnavailable), 5 * time.Minute, true},
+		{"wrapped status code", fmt.Errorf("unable to get file: %w", apiError(http.StatusServiceUnavailable)), 5 * time.Minute, true},
+		{"status code not configured", apiError(http.StatusBadGateway), 0, false},
+		{"configured status code overrides rate limit", apiError(http.StatusForbidden, "rateLimitExceeded"), 10 * time.Second, true},
+		{"rate limit", apiError(http.StatusTooManyRequests, "rateLimitExceeded"), time.Minute, true},
+		{"user rate limit", apiError(http.StatusTooManyRequests, "userRateLimitExceeded"), time.Minute, true},
+		{"other reason", apiError(http.StatusTooManyRequests, "insufficientFilePermissions"), 0, false},
+		{"rate limit reason with other status code", apiError(http.StatusBadRequest, "rateLimitExceeded"), 0, false},
+		{"not an API error", errors.New("connection reset by peer"), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxElapsedTime, retry := policy.maxElapsedTimeFor(tt.err)
+			if retry != tt.retry || maxElapsedTime != tt.maxElapsedTime {
+				t.Errorf("maxElapsedTimeFor() = %s, %t, want %s, %t", maxElapsedTime, retry, tt.maxElapsedTime, tt.retry)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	unavailable := apiError(http.StatusServiceUnavailable)
+	badGateway := apiError(http.StatusBadGateway)
+	rateLimit := apiError(http.StatusForbidden, "userRateLimitExceeded")
+	badRequest := apiError(http.StatusBadRequest)
+	tests := []struct {
+		name        string
+		statusCodes map[int]time.Duration
+		timeout     time.Duration
+		// errs are returned by the consecutive attempts, after the last one an attempt succeeds.
+		errs     []error
+		attempts int
+		err      error
+	}{
+		{
+			name:     "success",
+			attempts: 1,
+		},
+		{
+			name:        "retried status code",
+			statusCodes: map[int]time.Duration{http.StatusServiceUnavailable: time.Minute},
+			errs:        []error{unavailable, unavailable},
+			attempts:    3,
+		},
+		{
+			name:        "status code that is not retried",
+			statusCodes: map[int]time.Duration{http.StatusServiceUnavailable: time.Minute},
+			errs:        []error{unavailable, badRequest, unavailable},
+			attempts:    2,
+			err:         badRequest,
+		},
+		{
+			name:     "rate limit is always retried",
+			errs:     []error{rateLimit, rateLimit},
+			attempts: 3,
+		},
+		{
+			name: "maximum elapsed time per status code",
+			statusCodes: map[int]time.Duration{
+				http.StatusServiceUnavailable: time.Minute,
+				http.StatusBadGateway:         time.Microsecond,
+			},
+			errs:     []error{unavailable, badGateway, unavailable},
+			attempts: 2,
+			err:      badGateway,
+		},
+		{
+			name:        "context deadline before the next attempt",
+			statusCodes: map[int]time.Duration{http.StatusServiceUnavailable: time.Minute},
+			timeout:     time.Microsecond,
+			errs:        []error{unavailable, unavailable},
+			attempts:    1,
+			err:         unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &gdriveClient{retryPolicy: testRetryPolicy(tt.statusCodes)}
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			attempts := 0
+			r, err := retry(ctx, client, func(ctx context.Context) (int, error) {
+				attempts++
+				if got := retryAttempt(ctx); got != attempts {
+					t.Errorf("retryAttempt() = %d, want %d", got, attempts)
+				}
+				if attempts <= len(tt.errs) {
+					return 0, tt.errs[attempts-1]
+				}
+				return attempts, nil
+			})
+			if attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+			if err != tt.err {
+				t.Errorf("retry() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && r != tt.attempts {
+				t.Errorf("retry() = %d, want %d", r, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	client := &gdriveClient{retryPolicy: testRetryPolicy(map[int]time.Duration{http.StatusServiceUnavailable: 20 * time.Millisecond})}
+	unavailable := apiError(http.StatusServiceUnavailable)
+	attempts := 0
+	start := time.Now()
+	_, err := retry(context.Background(), client, func(ctx context.Context) (any, error) {
+		attempts++
+		return nil, unavailable
+	})
+	if err != unavailable {
+		t.Errorf("retry() error = %v, want %v", err, unavailable)
+	}
+	if attempts < 2 {
+		t.Errorf("attempts = %d, want at least 2", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond+time.Second {
+		t.Errorf("retry() took %s, which exceeds the maximum elapsed time", elapsed)
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenBucket is a simple token bucket rate limiter.
+// A nil *tokenBucket never blocks.
+type tokenBucket struct {
+	mu     sync.Mutex
+	qps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a tokenBucket that allows qps requests per second
+// or nil, if qps is not positive.
+func newTokenBucket(qps float64) *tokenBucket {
+	if qps <= 0 {
+		return nil
+	}
+	burst := math.Max(1, qps)
+	return &tokenBucket{
+		qps:    qps,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns the time the caller has to wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.qps)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.qps * float64(time.Second))
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	d := b.reserve()
+	if d == 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport limits the number of requests per second that are sent through it.
+// Read (GET / HEAD) and write requests have separate budgets.
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	read  *tokenBucket
+	write *tokenBucket
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := t.write
+	if isReadRequest(req) {
+		bucket = t.read
+	}
+	err := bucket.wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// isReadRequest returns true if req does not modify any data.
+func isReadRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// readOnlyTransport refuses every request that could modify data.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isReadRequest(req) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("the provider is configured with read_only = true, refusing to send %s %s", req.Method, req.URL.Redacted())
+	}
+	return t.base.RoundTrip(req)
+}
+
+// redacted replaces sensitive values in the logs.
+const redacted = "[REDACTED]"
+
+// loggingTransport logs every request and response with tflog, using the logger from the request's context.
+// Metadata is logged at DEBUG level, headers and JSON bodies at TRACE level.
+// Authorization headers, non-JSON bodies and the bodies of media requests (see isMediaRequest) are redacted.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+// isJSON returns true if the Content-Type header h describes a JSON document.
+func isJSON(h http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// isMediaRequest returns true if req uploads or downloads file content.
+// The bodies of these requests are never logged, because they may be large or contain sensitive data,
+// regardless of their Content-Type.
+func isMediaRequest(req *http.Request) bool {
+	query := req.URL.Query()
+	return strings.Contains(req.URL.Path, "/upload/") || strings.HasSuffix(req.URL.Path, "/export") ||
+		query.Has("uploadType") || query.Get("alt") == "media"
+}
+
+// redactHeaders returns a copy of h without credentials.
+func redactHeaders(h http.Header) map[string]any {
+	headers := map[string]any{}
+	for k, v := range h {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+			headers[k] = redacted
+		default:
+			headers[k] = v
+		}
+	}
+	return headers
+}
+
+// readBody reads body and returns its content together with a reader that replaces the consumed body.
+func readBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// errorReasons returns the reasons of a Google API error response body.
+func errorReasons(body []byte) []string {
+	var errorResponse struct {
+		Error struct {
+			Status string `json:"status"`
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+			Details []struct {
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errorResponse) != nil {
+		return nil
+	}
+	reasons := []string{}
+	for i := range errorResponse.Error.Errors {
+		reasons = append(reasons, errorResponse.Error.Errors[i].Reason)
+	}
+	for i := range errorResponse.Error.Details {
+		if errorResponse.Error.Details[i].Reason != "" {
+			reasons = append(reasons, errorResponse.Error.Details[i].Reason)
+		}
+	}
+	if len(reasons) == 0 && errorResponse.Error.Status != "" {
+		reasons = append(reasons, errorResponse.Error.Status)
+	}
+	return reasons
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]any{
+		"http_method":   req.Method,
+		"http_url":      req.URL.String(),
+		"retry_attempt": retryAttempt(req.Context()),
+	}
+	tflog.Debug(ctx, "Sending HTTP request", fields)
+	media := isMediaRequest(req)
+	requestBody := any(nil)
+	if req.Body != nil && req.Body != http.NoBody {
+		requestBody = redacted
+		if !media && isJSON(req.Header) {
+			b, body, err := readBody(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+			requestBody = string(b)
+		}
+	}
+	tflog.Trace(ctx, "HTTP request details", map[string]any{
+		"http_method":       req.Method,
+		"http_url":          req.URL.String(),
+		"http_headers":      redactHeaders(req.Header),
+		"http_request_body": requestBody,
+	})
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["latency"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "HTTP request failed", fields)
+		return resp, err
+	}
+	fields["http_status"] = resp.StatusCode
+	responseBody := any(redacted)
+	// error responses of media requests are small JSON documents and are logged like any other error
+	if isJSON(resp.Header) && (!media || resp.StatusCode >= 400) {
+		b, body, err := readBody(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = body
+		responseBody = string(b)
+		if resp.StatusCode >= 400 {
+			fields["error_reasons"] = errorReasons(b)
+		}
+	}
+	tflog.Debug(ctx, "Received HTTP response", fields)
+	tflog.Trace(ctx, "HTTP response details", map[string]any{
+		"http_method":        req.Method,
+		"http_url":           req.URL.String(),
+		"http_headers":       redactHeaders(resp.Header),
+		"http_response_body": responseBody,
+	})
+	return resp, nil
+}
+
+// baseTransport returns the transport of httpClient or the default transport, if it is not set.
+func baseTransport(httpClient *http.Client) http.RoundTripper {
+	if httpClient.Transport != nil {
+		return httpClient.Transport
+	}
+	return http.DefaultTransport
+}
+
+// withTransports returns a shallow copy of httpClient that sends all requests through
+// the logging transport and the transports enabled in config.
+// The read-only transport is the outermost one, so that refused requests are neither rate limited nor logged as sent.
+// The original client is left untouched.
+func withTransports(httpClient *http.Client, config *gdriveClientConfig) *http.Client {
+	var transport http.RoundTripper = &loggingTransport{
+		base: baseTransport(httpClient),
+	}
+	if config.readLimit != nil || config.writeLimit != nil {
+		transport = &rateLimitTransport{
+			base:  transport,
+			read:  config.readLimit,
+			write: config.writeLimit,
+		}
+	}
+	if config.readOnly {
+		transport = &readOnlyTransport{
+			base: transport,
+		}
+	}
+	c := *httpClient
+	c.Transport = transport
+	return &c
+}
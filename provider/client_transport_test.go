@@ -0,0 +1,377 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// countingTransport is a stub http.RoundTripper that records when requests are sent, without sending them.
+type countingTransport struct {
+	mu       sync.Mutex
+	requests map[string][]time.Time
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.requests == nil {
+		t.requests = map[string][]time.Time{}
+	}
+	t.requests[req.Method] = append(t.requests[req.Method], time.Now())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// count returns the number of requests with method that were sent in [from, to).
+func (t *countingTransport) count(method string, from, to time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for _, sent := range t.requests[method] {
+		if !sent.Before(from) && sent.Before(to) {
+			n++
+		}
+	}
+	return n
+}
+
+// send sends a request with method through client and returns the error of the round trip.
+func send(ctx context.Context, client *http.Client, method string) error {
+	req, err := http.NewRequestWithContext(ctx, method, "https://www.googleapis.com/drive/v3/files", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// sendBlocked returns true if a request with method is held back by the rate limit for at least 50ms.
+func sendBlocked(t *testing.T, client *http.Client, method string) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := send(ctx, client, method)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return err != nil
+}
+
+func TestNewTokenBucket(t *testing.T) {
+	if b := newTokenBucket(0); b != nil {
+		t.Errorf("newTokenBucket(0) = %v, want nil", b)
+	}
+	var b *tokenBucket
+	if err := b.wait(context.Background()); err != nil {
+		t.Errorf("nil tokenBucket wait() error = %v", err)
+	}
+	b = newTokenBucket(0.5)
+	if b.burst != 1 {
+		t.Errorf("newTokenBucket(0.5) burst = %g, want 1", b.burst)
+	}
+}
+
+func TestRateLimitTransportRequestsPerInterval(t *testing.T) {
+	const qps = 20
+	stub := &countingTransport{}
+	client := withTransports(&http.Client{Transport: stub}, &gdriveClientConfig{readLimit: newTokenBucket(qps)})
+	start := time.Now()
+	for i := 0; i < 2*qps; i++ {
+		if err := send(context.Background(), client, http.MethodGet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+	// the first qps requests use up the burst, the others are spread over the following second
+	if elapsed < 900*time.Millisecond {
+		t.Errorf("sending %d requests took %s, want at least 900ms", 2*qps, elapsed)
+	}
+	interval := 250 * time.Millisecond
+	for from := start.Add(interval); from.Before(start.Add(elapsed)); from = from.Add(interval) {
+		if n := stub.count(http.MethodGet, from, from.Add(interval)); n > qps/4+1 {
+			t.Errorf("%d requests were sent in the interval starting at %s, want at most %d", n, from.Sub(start), qps/4+1)
+		}
+	}
+}
+
+func TestRateLimitTransportReadWriteSplit(t *testing.T) {
+	stub := &countingTransport{}
+	client := withTransports(&http.Client{Transport: stub}, &gdriveClientConfig{
+		readLimit:  newTokenBucket(1000),
+		writeLimit: newTokenBucket(2),
+	})
+	for i := 0; i < 2; i++ {
+		if err := send(context.Background(), client, http.MethodPost); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !sendBlocked(t, client, http.MethodPatch) {
+		t.Error("write request was not rate limited after the write budget was used up")
+	}
+	for i := 0; i < 10; i++ {
+		if sendBlocked(t, client, http.MethodGet) {
+			t.Fatal("read request was rate limited by the write budget")
+		}
+	}
+	now := time.Now()
+	if n := stub.count(http.MethodPost, time.Time{}, now) + stub.count(http.MethodPatch, time.Time{}, now); n != 2 {
+		t.Errorf("%d write requests were sent, want 2", n)
+	}
+	if n := stub.count(http.MethodGet, time.Time{}, now); n != 10 {
+		t.Errorf("%d read requests were sent, want 10", n)
+	}
+}
+
+func TestRateLimitTransportUnlimited(t *testing.T) {
+	stub := &countingTransport{}
+	client := withTransports(&http.Client{Transport: stub}, &gdriveClientConfig{
+		readLimit: newTokenBucket(1),
+	})
+	for i := 0; i < 10; i++ {
+		if sendBlocked(t, client, http.MethodDelete) {
+			t.Fatal("write request was rate limited without max_qps_write")
+		}
+	}
+}
+
+func TestRateLimitTransportSharedAcrossSubjects(t *testing.T) {
+	stub := &countingTransport{}
+	config := &gdriveClientConfig{
+		readLimit:  newTokenBucket(1),
+		writeLimit: newTokenBucket(1),
+	}
+	// newClient wraps the HTTP client of every impersonated subject with the same config
+	first := withTransports(&http.Client{Transport: stub}, config)
+	second := withTransports(&http.Client{Transport: stub}, config)
+	if sendBlocked(t, first, http.MethodGet) || sendBlocked(t, first, http.MethodPost) {
+		t.Fatal("first requests were rate limited")
+	}
+	if !sendBlocked(t, second, http.MethodGet) {
+		t.Error("read request of the second subject was not rate limited by the shared read budget")
+	}
+	if !sendBlocked(t, second, http.MethodPost) {
+		t.Error("write request of the second subject was not rate limited by the shared write budget")
+	}
+	other := withTransports(&http.Client{Transport: stub}, &gdriveClientConfig{writeLimit: newTokenBucket(1)})
+	if sendBlocked(t, other, http.MethodPost) {
+		t.Error("write request of a different provider instance was rate limited")
+	}
+}
+
+func TestIsMediaRequest(t *testing.T) {
+	tests := []struct {
+		url   string
+		media bool
+	}{
+		{"https://www.googleapis.com/drive/v3/files/abc", false},
+		{"https://www.googleapis.com/drive/v3/files?q=trashed%3Dfalse", false},
+		{"https://www.googleapis.com/drive/v3/files/abc?alt=json", false},
+		{"https://www.googleapis.com/drive/v3/files/abc?alt=media", true},
+		{"https://www.googleapis.com/drive/v3/files/abc/export?mimeType=text%2Fcsv", true},
+		{"https://www.googleapis.com/upload/drive/v3/files?uploadType=multipart", true},
+		{"https://www.googleapis.com/upload/drive/v3/files/abc", true},
+		{"https://www.googleapis.com/drive/v3/files?uploadType=resumable&upload_id=xyz", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if media := isMediaRequest(req); media != tt.media {
+				t.Errorf("isMediaRequest() = %t, want %t", media, tt.media)
+			}
+		})
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	const (
+		token  = "ya29.secret-token"
+		cookie = "session=secret-cookie"
+		secret = "secret-content"
+	)
+	tests := []struct {
+		name                string
+		method              string
+		path                string
+		contentType         string
+		body                string
+		responseContentType string
+		responseStatus      int
+		responseBody        string
+		// logged are strings that must appear in the logs, secret must never appear.
+		logged []string
+	}{
+		{
+			name:                "JSON metadata",
+			method:              http.MethodPost,
+			path:                "/drive/v3/files",
+			contentType:         "application/json",
+			body:                `{"name":"report"}`,
+			responseContentType: "application/json; charset=UTF-8",
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"id":"file-id"}`,
+			logged:              []string{`{\"name\":\"report\"}`, `{\"id\":\"file-id\"}`},
+		},
+		{
+			name:                "JSON error",
+			method:              http.MethodGet,
+			path:                "/drive/v3/files/abc",
+			responseContentType: "application/json",
+			responseStatus:      http.StatusNotFound,
+			responseBody:        `{"error":{"code":404,"errors":[{"reason":"notFound"}]}}`,
+			logged:              []string{`"error_reasons":["notFound"]`},
+		},
+		{
+			name:                "multipart upload",
+			method:              http.MethodPost,
+			path:                "/upload/drive/v3/files?uploadType=multipart",
+			contentType:         "multipart/related; boundary=xyz",
+			body:                secret,
+			responseContentType: "application/json",
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"id":"file-id"}`,
+		},
+		{
+			name:                "JSON upload",
+			method:              http.MethodPatch,
+			path:                "/upload/drive/v3/files/abc?uploadType=media",
+			contentType:         "application/json",
+			body:                `{"content":"` + secret + `"}`,
+			responseContentType: "application/json",
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"id":"abc"}`,
+		},
+		{
+			name:                "JSON download",
+			method:              http.MethodGet,
+			path:                "/drive/v3/files/abc?alt=media",
+			responseContentType: "application/json",
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"content":"` + secret + `"}`,
+		},
+		{
+			name:                "export",
+			method:              http.MethodGet,
+			path:                "/drive/v3/files/abc/export?mimeType=text%2Fplain",
+			responseContentType: "text/plain",
+			responseStatus:      http.StatusOK,
+			responseBody:        secret,
+		},
+		{
+			name:                "non-JSON bodies",
+			method:              http.MethodPut,
+			path:                "/drive/v3/files/abc",
+			contentType:         "text/plain",
+			body:                secret,
+			responseContentType: "text/html",
+			responseStatus:      http.StatusOK,
+			responseBody:        "<p>" + secret + "</p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				received = string(b)
+				w.Header().Set("Content-Type", tt.responseContentType)
+				w.Header().Set("Set-Cookie", cookie)
+				w.WriteHeader(tt.responseStatus)
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+			var logs bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &logs)
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL+tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Cookie", cookie)
+			client := withTransports(server.Client(), &gdriveClientConfig{})
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if received != tt.body {
+				t.Errorf("server received body %q, want %q", received, tt.body)
+			}
+			if string(b) != tt.responseBody {
+				t.Errorf("client received body %q, want %q", b, tt.responseBody)
+			}
+			entries, err := tflogtest.MultilineJSONDecode(&logs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 4 {
+				t.Errorf("got %d log entries, want 4", len(entries))
+			}
+			output, err := json.Marshal(entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range []string{token, "secret-cookie", secret} {
+				if strings.Contains(string(output), s) {
+					t.Errorf("logs contain %q: %s", s, output)
+				}
+			}
+			for _, s := range tt.logged {
+				if !strings.Contains(string(output), s) {
+					t.Errorf("logs do not contain %q: %s", s, output)
+				}
+			}
+		})
+	}
+}
@@ -20,10 +20,10 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -35,7 +35,16 @@ func newDriveDataSource() datasource.DataSource {
 
 // driveDataSource defines the data source implementation.
 type driveDataSource struct {
-	client *http.Client
+	client *gdriveClient
+}
+
+// gdriveDriveDataSourceModel describes the data source data model.
+type gdriveDriveDataSourceModel struct {
+	Restrictions         *driveRestrictionsModel `tfsdk:"restrictions"`
+	Name                 types.String            `tfsdk:"name"`
+	DriveId              types.String            `tfsdk:"drive_id"`
+	Id                   types.String            `tfsdk:"id"`
+	UseDomainAdminAccess types.Bool              `tfsdk:"use_domain_admin_access"`
 }
 
 func (d *driveDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -70,12 +79,12 @@ func (d *driveDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -85,15 +94,18 @@ func (d *driveDataSource) Configure(ctx context.Context, req datasource.Configur
 }
 
 func (ds *driveDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	config := &gdriveDriveResourceModelV1{}
+	config := &gdriveDriveDataSourceModel{}
 	resp.Diagnostics.Append(req.Config.Get(ctx, config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	config.Id = config.DriveId
-	resp.Diagnostics.Append(config.populate()...)
-	if resp.Diagnostics.HasError() {
+	d, err := ds.client.getDrive(ctx, config.DriveId.ValueString(), fieldsDrive, config.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get drive, got error: %s", err))
 		return
 	}
+	config.Id = config.DriveId
+	config.Name = types.StringValue(d.Name)
+	config.Restrictions = driveRestrictions(d)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
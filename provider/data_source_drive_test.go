@@ -51,6 +51,9 @@ func TestAccDriveDS(t *testing.T) {
 					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.drive_members_only", restrictionsAfter),
 					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.copy_requires_writer_permission", restrictionsAfter),
 					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.domain_users_only", restrictionsAfter),
+					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.sharing_folders_requires_organizer_permission", restrictionsAfter),
+					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_readers", restrictionsAfter),
+					resource.TestCheckResourceAttr("data.gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_writers", restrictionsAfter),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -81,6 +84,10 @@ resource "gdrive_drive" "drive_restrictions" {
     drive_members_only              = %s
     copy_requires_writer_permission = %s
     domain_users_only               = %s
+
+    sharing_folders_requires_organizer_permission = %s
+    download_restricted_for_readers               = %s
+    download_restricted_for_writers               = %s
   }
-}`, name, name, restrictions, restrictions, restrictions, restrictions)
+}`, name, name, restrictions, restrictions, restrictions, restrictions, restrictions, restrictions, restrictions)
 }
@@ -20,9 +20,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,7 +35,7 @@ func newDrivesDataSource() datasource.DataSource {
 
 // drivesDataSource defines the data source implementation.
 type drivesDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdriveDriveResourceModelV1 describes the resource data model V1.
@@ -108,12 +106,12 @@ func (d *drivesDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -129,25 +127,19 @@ func (ds *drivesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 	query := config.Query.ValueString()
-	r, err := gsmdrive.ListDrives(query, fmt.Sprintf("drives(%s),nextPageToken", fieldsDrive), config.UseDomainAdminAccess.ValueBool(), 1)
-	for d := range r {
+	r, err := ds.client.listDrives(ctx, query, fmt.Sprintf("drives(%s),nextPageToken", fieldsDrive), config.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list Shared Drives, got error: %s", err))
+		return
+	}
+	for _, d := range r {
 		config.Drives = append(config.Drives, &gdriveDrivesDataSourceDriveModel{
-			Name:    types.StringValue(d.Name),
-			Id:      types.StringValue(d.Id),
-			DriveId: types.StringValue(d.Id),
-			Restrictions: &driveRestrictionsModel{
-				AdminManagedRestrictions:     types.BoolValue(d.Restrictions.AdminManagedRestrictions),
-				CopyRequiresWriterPermission: types.BoolValue(d.Restrictions.CopyRequiresWriterPermission),
-				DomainUsersOnly:              types.BoolValue(d.Restrictions.DomainUsersOnly),
-				DriveMembersOnly:             types.BoolValue(d.Restrictions.DriveMembersOnly),
-			},
+			Name:         types.StringValue(d.Name),
+			Id:           types.StringValue(d.Id),
+			DriveId:      types.StringValue(d.Id),
+			Restrictions: toDriveRestrictionsModel(d.Restrictions),
 		})
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list Shared Drives, got error: %s", e))
-		return
-	}
 	config.Id = config.Query
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
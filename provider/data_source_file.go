@@ -20,9 +20,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"maps"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -31,6 +30,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// fieldsFileMetadata are the fields that are returned by the gdrive_file, gdrive_files and gdrive_file_by_path data sources.
+const fieldsFileMetadata = fieldsFile + ",size,createdTime,modifiedTime,owners(displayName,emailAddress),lastModifyingUser(displayName,emailAddress),webViewLink,webContentLink,iconLink,capabilities,contentRestrictions,exportLinks"
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &fileDataSource{}
 
@@ -40,7 +42,7 @@ func newFileDataSource() datasource.DataSource {
 
 // fileDataSource defines the data source implementation.
 type fileDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveFileDataSourceModel struct {
@@ -54,6 +56,7 @@ type gdriveFileDataSourceModel struct {
 	ExportMimeType types.String `tfsdk:"export_mime_type"`
 	LocalFilePath  types.String `tfsdk:"local_file_path"`
 	DriveId        types.String `tfsdk:"drive_id"`
+	fileMetadataModel
 }
 
 func (d *fileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -118,6 +121,7 @@ For a list of supported MIME types see https://developers.google.com/file/api/v3
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, dsFileMetadata())
 }
 
 func (d *fileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -126,12 +130,12 @@ func (d *fileDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -147,7 +151,7 @@ func (ds *fileDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	fileID := config.FileId.ValueString()
-	r, err := gsmdrive.GetFile(fileID, fieldsFile, "")
+	r, err := ds.client.getFile(ctx, fileID, fieldsFileMetadata)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get file, got error: %s", err))
 		return
@@ -161,8 +165,12 @@ func (ds *fileDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if len(r.Parents) > 0 {
 		config.Parent = types.StringValue(r.Parents[0])
 	}
+	resp.Diagnostics.Append(config.populateMetadata(r)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if !config.DownloadPath.IsNull() {
-		filePath, err := gsmdrive.DownloadFile(fileID, config.DownloadPath.ValueString(), false)
+		filePath, err := ds.client.downloadFile(ctx, fileID, config.DownloadPath.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download file, got error: %s", err))
 			return
@@ -170,7 +178,7 @@ func (ds *fileDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		config.LocalFilePath = types.StringValue(filePath)
 	}
 	if !config.ExportPath.IsNull() {
-		filePath, err := gsmdrive.ExportFile(fileID, config.ExportMimeType.ValueString(), config.ExportPath.ValueString())
+		filePath, err := ds.client.exportFile(ctx, fileID, config.ExportMimeType.ValueString(), config.ExportPath.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export file, got error: %s", err))
 			return
@@ -0,0 +1,133 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &fileByPathDataSource{}
+
+func newFileByPathDataSource() datasource.DataSource {
+	return &fileByPathDataSource{}
+}
+
+// fileByPathDataSource defines the data source implementation.
+type fileByPathDataSource struct {
+	client *gdriveClient
+}
+
+type gdriveFileByPathDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Path     types.String `tfsdk:"path"`
+	DriveId  types.String `tfsdk:"drive_id"`
+	FileId   types.String `tfsdk:"file_id"`
+	Parent   types.String `tfsdk:"parent"`
+	Name     types.String `tfsdk:"name"`
+	MimeType types.String `tfsdk:"mime_type"`
+	fileMetadataModel
+}
+
+func (d *fileByPathDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file_by_path"
+}
+
+func (d *fileByPathDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets a file or folder by its path, e.g. "Finance/Reports/2026/Q3.xlsx", and returns its metadata.
+
+The path is resolved one folder at a time. If a folder contains several files or folders with the same name as a part of the path, the path is ambiguous and an error is returned.`,
+		Attributes: map[string]schema.Attribute{
+			"id": dsId(),
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Slash separated path of the file, relative to the root of the Shared Drive or My Drive.",
+			},
+			"drive_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the Shared Drive the path is relative to. If unset, the path is relative to the root of the My Drive of the provider's subject.",
+			},
+			"file_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the file.",
+			},
+			"parent": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the file's parent.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the file.",
+			},
+			"mime_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type of the file.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, dsFileMetadata())
+}
+
+func (d *fileByPathDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gdriveClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (ds *fileByPathDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	config := &gdriveFileByPathDataSourceModel{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r, diags := resolvePath(ctx, ds.client, config.DriveId.ValueString(), config.Path.ValueString(), fieldsFileMetadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Id = types.StringValue(r.Id)
+	config.FileId = config.Id
+	config.Name = types.StringValue(r.Name)
+	config.MimeType = types.StringValue(r.MimeType)
+	if len(r.Parents) > 0 {
+		config.Parent = types.StringValue(r.Parents[0])
+	}
+	resp.Diagnostics.Append(config.populateMetadata(r)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
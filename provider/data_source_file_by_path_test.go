@@ -0,0 +1,115 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFileByPathDS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// 1 - Create files
+			{
+				Config: testAccFileByPathDataSourceConfig(""),
+			},
+			// 2 - Read testing
+			{
+				Config: testAccFileByPathDataSourceConfig(`
+data "gdrive_file_by_path" "file" {
+  drive_id = gdrive_drive.drive.drive_id
+  path     = "Finance/Reports/Q3.txt"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.gdrive_file_by_path.file", "file_id", "gdrive_file.file", "file_id"),
+					resource.TestCheckResourceAttrPair("data.gdrive_file_by_path.file", "parent", "gdrive_file.reports", "file_id"),
+					resource.TestCheckResourceAttr("data.gdrive_file_by_path.file", "name", "Q3.txt"),
+					resource.TestCheckResourceAttr("data.gdrive_file_by_path.file", "mime_type", "text/plain"),
+					resource.TestCheckResourceAttr("data.gdrive_file_by_path.file", "trashed", "false"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file_by_path.file", "web_view_link"),
+				),
+			},
+			// 3 - Ambiguous path
+			{
+				Config: testAccFileByPathDataSourceConfig(`
+data "gdrive_file_by_path" "file" {
+  drive_id = gdrive_drive.drive.drive_id
+  path     = "Finance/Reports/Duplicate.txt"
+}
+`),
+				ExpectError: regexp.MustCompile("Ambiguous Path"),
+			},
+			// 4 - Missing path
+			{
+				Config: testAccFileByPathDataSourceConfig(`
+data "gdrive_file_by_path" "file" {
+  drive_id = gdrive_drive.drive.drive_id
+  path     = "Finance/Missing/Q3.txt"
+}
+`),
+				ExpectError: regexp.MustCompile("File Not Found"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFileByPathDataSourceConfig(dataSource string) string {
+	return `resource "gdrive_drive" "drive" {
+  name                    = "file by path test"
+  use_domain_admin_access = true
+}
+
+resource "gdrive_file" "finance" {
+  name      = "Finance"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_file" "reports" {
+  name      = "Reports"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_file.finance.file_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_file" "file" {
+  name           = "Q3.txt"
+  mime_type      = "text/plain"
+  parent         = gdrive_file.reports.file_id
+  drive_id       = gdrive_drive.drive.drive_id
+  content_string = "Q3"
+}
+
+resource "gdrive_file" "duplicate" {
+  count          = 2
+  name           = "Duplicate.txt"
+  mime_type      = "text/plain"
+  parent         = gdrive_file.reports.file_id
+  drive_id       = gdrive_drive.drive.drive_id
+  content_string = "duplicate"
+}
+` + dataSource
+}
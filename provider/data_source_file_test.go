@@ -53,6 +53,16 @@ c3,c4
 					resource.TestCheckResourceAttr("data.gdrive_file.file_metadata", "mime_type", "application/vnd.google-apps.folder"),
 					resource.TestCheckResourceAttr("data.gdrive_file.file_download", "mime_type", "text/plain"),
 					resource.TestCheckResourceAttr("data.gdrive_file.file_export", "mime_type", "application/vnd.google-apps.spreadsheet"),
+					resource.TestCheckResourceAttr("data.gdrive_file.file_metadata", "trashed", "false"),
+					resource.TestCheckResourceAttr("data.gdrive_file.file_metadata", "capabilities.can_add_children", "true"),
+					resource.TestCheckNoResourceAttr("data.gdrive_file.file_metadata", "size"),
+					resource.TestCheckResourceAttr("data.gdrive_file.file_download", "size", fmt.Sprint(len(content))),
+					resource.TestCheckResourceAttrPair("data.gdrive_file.file_download", "md5_checksum", "gdrive_file.file_with_content", "content_md5"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file.file_download", "web_view_link"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file.file_download", "web_content_link"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file.file_download", "created_time"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file.file_download", "last_modifying_user.email_address"),
+					resource.TestCheckResourceAttrSet("data.gdrive_file.file_export", "export_links.text/csv"),
 				),
 			},
 			// 2 - Rename
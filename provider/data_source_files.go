@@ -20,9 +20,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"maps"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,7 +36,7 @@ func newFilesDataSource() datasource.DataSource {
 
 // filesDataSource defines the data source implementation.
 type filesDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdriveDriveResourceModelV1 describes the resource data model V1.
@@ -48,6 +47,7 @@ type gdriveFilesDataSourceFileModel struct {
 	Id       types.String `tfsdk:"id"`
 	DriveId  types.String `tfsdk:"drive_id"`
 	MimeType types.String `tfsdk:"mime_type"`
+	fileMetadataModel
 }
 
 // gdriveDriveResourceModelV1 describes the resource data model V1.
@@ -133,6 +133,8 @@ When able, use 'user' or 'drive', instead of 'allDrives', for efficiency.`,
 			},
 		},
 	}
+	files := resp.Schema.Attributes["files"].(schema.SetNestedAttribute)
+	maps.Copy(files.NestedObject.Attributes, dsFileMetadata())
 }
 
 func (d *filesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -141,12 +143,12 @@ func (d *filesDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -162,8 +164,12 @@ func (ds *filesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	query := config.Query.ValueString()
-	r, err := gsmdrive.ListFiles(query, config.DriveId.ValueString(), config.Corpora.ValueString(), "", "", config.Spaces.ValueString(), fmt.Sprintf("files(%s),nextPageToken", fieldsFile), config.IncludeItemsFromAllDrives.ValueBool(), 1)
-	for f := range r {
+	r, err := ds.client.listFiles(ctx, query, config.DriveId.ValueString(), config.Corpora.ValueString(), config.Spaces.ValueString(), fmt.Sprintf("files(%s),nextPageToken", fieldsFileMetadata), config.IncludeItemsFromAllDrives.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list files, got error: %s", err))
+		return
+	}
+	for _, f := range r {
 		fileModel := &gdriveFilesDataSourceFileModel{
 			Name:     types.StringValue(f.Name),
 			Id:       types.StringValue(f.Id),
@@ -176,13 +182,12 @@ func (ds *filesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		if f.DriveId != "" {
 			fileModel.DriveId = types.StringValue(f.DriveId)
 		}
+		resp.Diagnostics.Append(fileModel.populateMetadata(f)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		config.Files = append(config.Files, fileModel)
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list files, got error: %s", e))
-		return
-	}
 	config.Id = config.Query
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
@@ -48,6 +48,9 @@ func TestAccFiles(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.gdrive_files.files", "files.0.name", name),
 					resource.TestCheckResourceAttr("data.gdrive_files.files", "files.0.mime_type", "application/vnd.google-apps.folder"),
+					resource.TestCheckResourceAttr("data.gdrive_files.files", "files.0.trashed", "false"),
+					resource.TestCheckResourceAttrSet("data.gdrive_files.files", "files.0.web_view_link"),
+					resource.TestCheckResourceAttrSet("data.gdrive_files.files", "files.0.modified_time"),
 				),
 			},
 			// 3 - Delete files
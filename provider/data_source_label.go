@@ -20,9 +20,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -38,7 +36,7 @@ func newLabelDataSource() datasource.DataSource {
 
 // labelDataSource defines the data source implementation.
 type labelDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelListOptionsModel struct {
@@ -197,12 +195,12 @@ func (d *labelDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -221,7 +219,7 @@ func (ds *labelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if !config.Revision.IsNull() {
 		labelID += "@" + config.Revision.ValueString()
 	}
-	l, err := gsmdrivelabels.GetLabel(labelID, config.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", config.UseAdminAccess.ValueBool())
+	l, err := ds.client.getLabel(ctx, labelID, config.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", config.UseAdminAccess.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get label, got error: %s", err))
 		return
@@ -20,9 +20,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,7 +35,7 @@ func newLabelsDataSource() datasource.DataSource {
 
 // labelsDataSource defines the data source implementation.
 type labelsDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelsDataSourceLabelModel struct {
@@ -129,12 +127,12 @@ func (d *labelsDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -149,8 +147,12 @@ func (ds *labelsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	r, err := gsmdrivelabels.ListLabels(config.LanguageCode.ValueString(), "LABEL_VIEW_FULL", config.MinumumRole.ValueString(), "*", config.UseAdminAccess.ValueBool(), config.PublishedOnly.ValueBool(), 1)
-	for l := range r {
+	r, err := ds.client.listDriveLabels(ctx, config.LanguageCode.ValueString(), "LABEL_VIEW_FULL", config.MinumumRole.ValueString(), "*", config.UseAdminAccess.ValueBool(), config.PublishedOnly.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list labels, got error: %s", err))
+		return
+	}
+	for _, l := range r {
 		label := &gdriveLabelsDataSourceLabelModel{
 			Id:         types.StringValue(l.Id),
 			LabelId:    types.StringValue(l.Id),
@@ -163,11 +165,6 @@ func (ds *labelsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		label.Properties.populate(l.Properties)
 		config.Labels = append(config.Labels, label)
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list labels, got error: %s", e))
-		return
-	}
 	config.Id = types.StringValue("1")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
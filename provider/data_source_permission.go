@@ -20,9 +20,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,7 +35,7 @@ func newPermissionDataSource() datasource.DataSource {
 
 // permissionDataSource defines the data source implementation.
 type permissionDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdrivePermissionDataSourceModel struct {
@@ -98,12 +96,12 @@ func (d *permissionDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -120,7 +118,7 @@ func (ds *permissionDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 	fileID := config.FileId.ValueString()
 	permissionID := config.PermissionId.ValueString()
-	r, err := gsmdrive.GetPermission(fileID, permissionID, "emailAddress,domain,role,type,id", config.UseDomainAdminAccess.ValueBool())
+	r, err := ds.client.getPermission(ctx, fileID, permissionID, "emailAddress,domain,role,type,id", config.UseDomainAdminAccess.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get permission, got error: %s", err))
 		return
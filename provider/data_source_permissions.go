@@ -20,9 +20,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,7 +35,7 @@ func newPermissionsDataSource() datasource.DataSource {
 
 // permissionsDataSource defines the data source implementation.
 type permissionsDataSource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdrivePermissionsDataSourcePermissionModel struct {
@@ -133,12 +131,12 @@ func (d *permissionsDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -154,8 +152,12 @@ func (ds *permissionsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 	fileID := config.FileId.ValueString()
-	r, err := gsmdrive.ListPermissions(fileID, "", "permissions(id,displayName,domain,deleted,emailAddress,expirationTime,role,type),nextPageToken", config.UseDomainAdminAccess.ValueBool(), 1)
-	for p := range r {
+	r, err := ds.client.listPermissions(ctx, fileID, "permissions(id,displayName,domain,deleted,emailAddress,expirationTime,role,type),nextPageToken", config.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions, got error: %s", err))
+		return
+	}
+	for _, p := range r {
 		config.Permissions = append(config.Permissions, &gdrivePermissionsDataSourcePermissionModel{
 			PermissionId:   types.StringValue(p.Id),
 			DisplayName:    types.StringValue(p.DisplayName),
@@ -167,11 +169,6 @@ func (ds *permissionsDataSource) Read(ctx context.Context, req datasource.ReadRe
 			Deleted:        types.BoolValue(p.Deleted),
 		})
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions, got error: %s", e))
-		return
-	}
 	config.Id = config.FileId
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
@@ -18,33 +18,156 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"google.golang.org/api/drive/v3"
 )
 
-func (driveModel *gdriveDriveResourceModelV1) populate() (diags diag.Diagnostics) {
-	d, err := gsmdrive.GetDrive(driveModel.Id.ValueString(), fieldsDrive, driveModel.UseDomainAdminAccess.ValueBool())
+// createDrive creates the Shared Drive with requestID.
+// requestID is generated once per Create call, so repeated and retried requests within that call cannot create a duplicate.
+// It is not persisted, so a new apply after a failed Create uses a new request ID.
+// If the API reports that requestID was already used, because a previous attempt succeeded but the response was lost,
+// findCreatedDrive is used to look up the Shared Drive that was created by that attempt.
+func (driveModel *gdriveDriveResourceModelV1) createDrive(ctx context.Context, client *gdriveClient, requestID string, driveReq *drive.Drive) (d *drive.Drive, diags diag.Diagnostics) {
+	start := time.Now()
+	d, err := client.createDrive(ctx, requestID, driveReq, fieldsDrive)
+	if err != nil && !isAPIError(err) && ctx.Err() == nil {
+		// The request may have been processed. Sending it again with the same request ID does not create a duplicate.
+		tflog.Warn(ctx, "Repeating create request for Shared Drive", map[string]any{"request_id": requestID, "error": err.Error()})
+		d, err = client.createDrive(ctx, requestID, driveReq, fieldsDrive)
+	}
+	if isConflict(err) {
+		tflog.Info(ctx, "Shared Drive was already created with this request ID", map[string]any{"request_id": requestID})
+		d, err = driveModel.findCreatedDrive(ctx, client, start)
+	}
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to get drive, got error: %s", err))
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create drive, got error: %s", err))
 		return
 	}
-	driveModel.Name = types.StringValue(d.Name)
-	if d.Restrictions != nil && (d.Restrictions.AdminManagedRestrictions || d.Restrictions.CopyRequiresWriterPermission || d.Restrictions.DomainUsersOnly || d.Restrictions.DriveMembersOnly) {
-		driveModel.Restrictions = &driveRestrictionsModel{
-			AdminManagedRestrictions:     types.BoolValue(d.Restrictions.AdminManagedRestrictions),
-			CopyRequiresWriterPermission: types.BoolValue(d.Restrictions.CopyRequiresWriterPermission),
-			DomainUsersOnly:              types.BoolValue(d.Restrictions.DomainUsersOnly),
-			DriveMembersOnly:             types.BoolValue(d.Restrictions.DriveMembersOnly),
+	return d, diags
+}
+
+// findCreatedDrive returns the Shared Drive with the name of driveModel that was created after start.
+// This is a best-effort heuristic: the API does not return the Shared Drive that belongs to a request ID,
+// so a Shared Drive with the same name that was created at the same time by someone else cannot be told apart.
+// If not exactly one Shared Drive matches, an error explains how to import the right one.
+// The clock of the local machine may differ from Google's, so a minute of skew is allowed.
+func (driveModel *gdriveDriveResourceModelV1) findCreatedDrive(ctx context.Context, client *gdriveClient, start time.Time) (*drive.Drive, error) {
+	name := driveModel.Name.ValueString()
+	drives, err := client.listDrives(ctx, fmt.Sprintf("name = '%s'", escapeQuery(name)), fmt.Sprintf("drives(%s,createdTime),nextPageToken", fieldsDrive), driveModel.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		return nil, err
+	}
+	created := []*drive.Drive{}
+	for _, d := range drives {
+		createdTime, err := time.Parse(time.RFC3339, d.CreatedTime)
+		if err != nil {
+			return nil, err
 		}
+		if d.Name == name && createdTime.After(start.Add(-time.Minute)) {
+			created = append(created, d)
+		}
+	}
+	if len(created) != 1 {
+		ids := make([]string, len(created))
+		for i := range created {
+			ids[i] = created[i].Id
+		}
+		return nil, fmt.Errorf("the Shared Drive was already created by a previous attempt, but it could not be identified, because %d Shared Drives named %q were created since %s (IDs: [%s]). "+
+			"Find the ID of the Shared Drive and import it with: terraform import <resource address> %t,<drive_id>",
+			len(created), name, start.Format(time.RFC3339), strings.Join(ids, ", "), driveModel.UseDomainAdminAccess.ValueBool())
+	}
+	return created[0], nil
+}
+
+// waitForDrive waits until a newly created Shared Drive can be found by the API.
+// The Drive API is not strongly consistent, so other requests may return 404 for a while after a Shared Drive was created.
+// It polls drives.get and files.list with the backoff strategy of the retry policy until both succeed.
+func waitForDrive(ctx context.Context, client *gdriveClient, driveID string, useDomainAdminAccess bool) error {
+	client = client.withRetryOn(http.StatusNotFound)
+	_, err := client.getDrive(ctx, driveID, "id", useDomainAdminAccess)
+	if err != nil {
+		return err
+	}
+	_, err = client.listFiles(ctx, fmt.Sprintf("'%s' in parents", driveID), driveID, "drive", "", "files(id),nextPageToken", true)
+	return err
+}
+
+// emptyDrive deletes all files and folders in the Shared Drive driveID, including the ones in the trash.
+// Deleting a folder deletes its descendants, so only the items at the root of the Shared Drive are deleted.
+func emptyDrive(ctx context.Context, client *gdriveClient, driveID string) error {
+	files, err := client.listFiles(ctx, fmt.Sprintf("'%s' in parents", driveID), driveID, "drive", "", "files(id,name),nextPageToken", true)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		tflog.Info(ctx, "Deleting item in Shared Drive", map[string]any{"drive_id": driveID, "file_id": f.Id, "name": f.Name})
+		err = client.deleteFile(ctx, f.Id)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("unable to delete %s (%s): %w", f.Name, f.Id, err)
+		}
+	}
+	return nil
+}
+
+func (driveModel *gdriveDriveResourceModelV1) populate(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
+	d, err := client.getDrive(ctx, driveModel.Id.ValueString(), fieldsDrive, driveModel.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		diags.Append(clientErrorDiagnostic("Unable to get drive", err))
+		return
+	}
+	driveModel.Name = types.StringValue(d.Name)
+	// An all-false restrictions block in the configuration is kept, so that it does not disappear from the state.
+	if d.Restrictions != nil && (driveModel.Restrictions != nil || hasDriveRestrictions(d.Restrictions)) {
+		driveModel.Restrictions = toDriveRestrictionsModel(d.Restrictions)
 	}
+	driveModel.ColorRgb = types.StringValue(d.ColorRgb)
+	driveModel.Hidden = types.BoolValue(d.Hidden)
 	return diags
 }
 
+// driveRestrictions returns the restrictions of d or nil, if none are set.
+func driveRestrictions(d *drive.Drive) *driveRestrictionsModel {
+	if d.Restrictions == nil || !hasDriveRestrictions(d.Restrictions) {
+		return nil
+	}
+	return toDriveRestrictionsModel(d.Restrictions)
+}
+
+// hasDriveRestrictions returns true if at least one of the restrictions in r is set.
+func hasDriveRestrictions(r *drive.DriveRestrictions) bool {
+	if r.AdminManagedRestrictions || r.CopyRequiresWriterPermission || r.DomainUsersOnly || r.DriveMembersOnly || r.SharingFoldersRequiresOrganizerPermission {
+		return true
+	}
+	return r.DownloadRestriction != nil && (r.DownloadRestriction.RestrictedForReaders || r.DownloadRestriction.RestrictedForWriters)
+}
+
+// toDriveRestrictionsModel converts r to the data model.
+// A missing download restriction is treated as not restricted.
+func toDriveRestrictionsModel(r *drive.DriveRestrictions) *driveRestrictionsModel {
+	downloadRestriction := r.DownloadRestriction
+	if downloadRestriction == nil {
+		downloadRestriction = &drive.DownloadRestriction{}
+	}
+	return &driveRestrictionsModel{
+		AdminManagedRestrictions:                  types.BoolValue(r.AdminManagedRestrictions),
+		CopyRequiresWriterPermission:              types.BoolValue(r.CopyRequiresWriterPermission),
+		DomainUsersOnly:                           types.BoolValue(r.DomainUsersOnly),
+		DriveMembersOnly:                          types.BoolValue(r.DriveMembersOnly),
+		SharingFoldersRequiresOrganizerPermission: types.BoolValue(r.SharingFoldersRequiresOrganizerPermission),
+		DownloadRestrictedForReaders:              types.BoolValue(downloadRestriction.RestrictedForReaders),
+		DownloadRestrictedForWriters:              types.BoolValue(downloadRestriction.RestrictedForWriters),
+	}
+}
+
 func (restrictionsModel *driveRestrictionsModel) toDriveRestrictions() *drive.DriveRestrictions {
 	restrictions := &drive.DriveRestrictions{}
 	if !restrictionsModel.AdminManagedRestrictions.IsNull() {
@@ -71,9 +194,44 @@ func (restrictionsModel *driveRestrictionsModel) toDriveRestrictions() *drive.Dr
 			restrictions.ForceSendFields = append(restrictions.ForceSendFields, "DriveMembersOnly")
 		}
 	}
+	if !restrictionsModel.SharingFoldersRequiresOrganizerPermission.IsNull() {
+		restrictions.SharingFoldersRequiresOrganizerPermission = restrictionsModel.SharingFoldersRequiresOrganizerPermission.ValueBool()
+		if !restrictions.SharingFoldersRequiresOrganizerPermission {
+			restrictions.ForceSendFields = append(restrictions.ForceSendFields, "SharingFoldersRequiresOrganizerPermission")
+		}
+	}
+	if !restrictionsModel.DownloadRestrictedForReaders.IsNull() || !restrictionsModel.DownloadRestrictedForWriters.IsNull() {
+		restrictions.DownloadRestriction = &drive.DownloadRestriction{
+			RestrictedForReaders: restrictionsModel.DownloadRestrictedForReaders.ValueBool(),
+			RestrictedForWriters: restrictionsModel.DownloadRestrictedForWriters.ValueBool(),
+			ForceSendFields:      []string{"RestrictedForReaders", "RestrictedForWriters"},
+		}
+	}
 	return restrictions
 }
 
+func (imageModel *driveBackgroundImageFileModel) toBackgroundImageFile() *drive.DriveBackgroundImageFile {
+	return &drive.DriveBackgroundImageFile{
+		Id:              imageModel.Id.ValueString(),
+		XCoordinate:     imageModel.XCoordinate.ValueFloat64(),
+		YCoordinate:     imageModel.YCoordinate.ValueFloat64(),
+		Width:           imageModel.Width.ValueFloat64(),
+		ForceSendFields: []string{"XCoordinate", "YCoordinate", "Width"},
+	}
+}
+
+// changed reports whether a background image is set and differs from the one in state.
+func (imageModel *driveBackgroundImageFileModel) changed(state *driveBackgroundImageFileModel) bool {
+	if imageModel == nil {
+		return false
+	}
+	return state == nil ||
+		!imageModel.Id.Equal(state.Id) ||
+		!imageModel.XCoordinate.Equal(state.XCoordinate) ||
+		!imageModel.YCoordinate.Equal(state.YCoordinate) ||
+		!imageModel.Width.Equal(state.Width)
+}
+
 func dsDriveRestrictions() schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
 		Computed:            true,
@@ -97,6 +255,19 @@ This restriction may be overridden by other sharing policies controlled outside
 				Computed:            true,
 				MarkdownDescription: "Whether access to items inside this shared drive is restricted to its members.",
 			},
+			"sharing_folders_requires_organizer_permission": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "If true, only users with the organizer role can share folders. If false, users with either the organizer role or the file organizer role can share folders.",
+			},
+			"download_restricted_for_readers": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether downloading and copying files inside this shared drive is restricted for readers and commenters.",
+			},
+			"download_restricted_for_writers": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: `Whether downloading and copying files inside this shared drive is restricted for writers.
+If true, downloading is restricted for readers as well.`,
+			},
 		},
 	}
 }
@@ -0,0 +1,567 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/drive/v3"
+)
+
+const mimeTypeFolder = "application/vnd.google-apps.folder"
+
+// isGoogleWorkspaceType reports whether mimeType is a Google Docs, Sheets, Slides, etc. type.
+// Files of these types have no md5Checksum in Drive.
+func isGoogleWorkspaceType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "application/vnd.google-apps.")
+}
+
+// readSeekNopCloser adds a no-op Close method to content that is held in memory.
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error {
+	return nil
+}
+
+// hasContent reports whether content, content_string or content_base64 is set.
+func (fileModel *gdriveFileResourceModel) hasContent() bool {
+	return !fileModel.Content.IsNull() || !fileModel.ContentString.IsNull() || !fileModel.ContentBase64.IsNull()
+}
+
+// openContent returns the content to upload, either the local file (content) or the
+// in-memory content of content_string or content_base64.
+// It returns nil if none of them is set.
+func (fileModel *gdriveFileResourceModel) openContent() (io.ReadSeekCloser, error) {
+	switch {
+	case !fileModel.Content.IsNull():
+		f, err := os.Open(fileModel.Content.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	case !fileModel.ContentString.IsNull():
+		return readSeekNopCloser{strings.NewReader(fileModel.ContentString.ValueString())}, nil
+	case !fileModel.ContentBase64.IsNull():
+		b, err := base64.StdEncoding.DecodeString(fileModel.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 is not valid base64: %w", err)
+		}
+		return readSeekNopCloser{bytes.NewReader(b)}, nil
+	}
+	return nil, nil
+}
+
+// sourceHash returns the hex encoded MD5 hash of the content to upload.
+// It is unknown if the content is not known yet and null if there is no content.
+func (fileModel *gdriveFileResourceModel) sourceHash() (types.String, error) {
+	if fileModel.Content.IsUnknown() || fileModel.ContentString.IsUnknown() || fileModel.ContentBase64.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+	content, err := fileModel.openContent()
+	if err != nil {
+		return types.StringUnknown(), err
+	}
+	if content == nil {
+		return types.StringNull(), nil
+	}
+	defer content.Close()
+	hash, err := md5Hash(content)
+	if err != nil {
+		return types.StringUnknown(), err
+	}
+	return types.StringValue(hash), nil
+}
+
+// md5Hash returns the hex encoded MD5 hash of content, which matches the md5Checksum of Drive.
+func md5Hash(content io.Reader) (string, error) {
+	h := md5.New()
+	_, err := io.Copy(h, content)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stringValueOrNull returns a null string instead of an empty one.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// contentChanged reports whether the content has to be uploaded again.
+// This is the case if the configured content, the local file or the content of the file in Drive have changed.
+func (fileModel *gdriveFileResourceModel) contentChanged(state *gdriveFileResourceModel) bool {
+	if !fileModel.hasContent() {
+		return false
+	}
+	if !fileModel.Content.Equal(state.Content) || !fileModel.ContentString.Equal(state.ContentString) || !fileModel.ContentBase64.Equal(state.ContentBase64) || fileModel.SourceHash.IsUnknown() {
+		return true
+	}
+	if !state.SourceHash.IsNull() && !fileModel.SourceHash.Equal(state.SourceHash) {
+		return true
+	}
+	return !state.ContentMd5.IsNull() && !state.ContentMd5.Equal(fileModel.SourceHash)
+}
+
+// setSourceHash computes source_hash if it could not be computed during plan.
+func (fileModel *gdriveFileResourceModel) setSourceHash() (diags diag.Diagnostics) {
+	if !fileModel.SourceHash.IsUnknown() {
+		return
+	}
+	sourceHash, err := fileModel.sourceHash()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to hash content, got error: %s", err))
+		return
+	}
+	fileModel.SourceHash = sourceHash
+	return
+}
+
+func (shortcutModel *gdriveShortcutResourceModel) populate(f *drive.File) {
+	shortcutModel.Id = types.StringValue(f.Id)
+	shortcutModel.ShortcutId = shortcutModel.Id
+	shortcutModel.Name = types.StringValue(f.Name)
+	if len(f.Parents) != 0 {
+		shortcutModel.Parent = types.StringValue(f.Parents[0])
+	}
+	shortcutModel.DriveId = stringValueOrNull(f.DriveId)
+	if f.ShortcutDetails != nil {
+		shortcutModel.TargetId = types.StringValue(f.ShortcutDetails.TargetId)
+		shortcutModel.TargetMimeType = stringValueOrNull(f.ShortcutDetails.TargetMimeType)
+		shortcutModel.TargetResourceKey = stringValueOrNull(f.ShortcutDetails.TargetResourceKey)
+	}
+}
+
+// stringMap converts a map of strings to a Go map.
+// It returns nil if m is null or unknown.
+func stringMap(m types.Map) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	result := map[string]string{}
+	for k, v := range m.Elements() {
+		result[k] = v.(types.String).ValueString()
+	}
+	return result
+}
+
+// stringMapValue converts a Go map to a map of strings.
+// An empty map is null, unless current is an empty map as well.
+func stringMapValue(m map[string]string, current types.Map) (types.Map, diag.Diagnostics) {
+	if len(m) == 0 {
+		if !current.IsNull() && !current.IsUnknown() && len(current.Elements()) == 0 {
+			return current, nil
+		}
+		return types.MapNull(types.StringType), nil
+	}
+	elements := map[string]attr.Value{}
+	for k, v := range m {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValue(types.StringType, elements)
+}
+
+// removedKeys returns the keys that are in state but not in plan.
+func removedKeys(plan, state types.Map) []string {
+	keys := []string{}
+	planElements := plan.Elements()
+	for k := range state.Elements() {
+		if _, ok := planElements[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// clearRemovedMetadata adds the fields to file that clear the description and
+// the properties and app properties that were removed from the configuration.
+func (fileModel *gdriveFileResourceModel) clearRemovedMetadata(file *drive.File, state *gdriveFileResourceModel) {
+	if fileModel.Description.IsNull() && !state.Description.IsNull() {
+		file.ForceSendFields = append(file.ForceSendFields, "Description")
+	}
+	// Maps without elements are only sent if they are in ForceSendFields.
+	for _, k := range removedKeys(fileModel.Properties, state.Properties) {
+		file.NullFields = append(file.NullFields, "Properties."+k)
+		file.ForceSendFields = append(file.ForceSendFields, "Properties")
+	}
+	for _, k := range removedKeys(fileModel.AppProperties, state.AppProperties) {
+		file.NullFields = append(file.NullFields, "AppProperties."+k)
+		file.ForceSendFields = append(file.ForceSendFields, "AppProperties")
+	}
+}
+
+// populateMetadata sets the metadata attributes of fileModel from f.
+func (fileModel *gdriveFileResourceModel) populateMetadata(f *drive.File) (diags diag.Diagnostics) {
+	fileModel.Description = stringValueOrNull(f.Description)
+	fileModel.FolderColorRgb = stringValueOrNull(f.FolderColorRgb)
+	fileModel.Starred = types.BoolValue(f.Starred)
+	fileModel.WritersCanShare = types.BoolValue(f.WritersCanShare)
+	fileModel.CopyRequiresWriterPermission = types.BoolValue(f.CopyRequiresWriterPermission)
+	var d diag.Diagnostics
+	fileModel.Properties, d = stringMapValue(f.Properties, fileModel.Properties)
+	diags.Append(d...)
+	fileModel.AppProperties, d = stringMapValue(f.AppProperties, fileModel.AppProperties)
+	diags.Append(d...)
+	return diags
+}
+
+// setComputedMetadata sets the computed metadata attributes that were not configured from f.
+func (fileModel *gdriveFileResourceModel) setComputedMetadata(f *drive.File) {
+	if fileModel.FolderColorRgb.IsUnknown() {
+		fileModel.FolderColorRgb = stringValueOrNull(f.FolderColorRgb)
+	}
+	if fileModel.Starred.IsUnknown() {
+		fileModel.Starred = types.BoolValue(f.Starred)
+	}
+	if fileModel.WritersCanShare.IsUnknown() {
+		fileModel.WritersCanShare = types.BoolValue(f.WritersCanShare)
+	}
+	if fileModel.CopyRequiresWriterPermission.IsUnknown() {
+		fileModel.CopyRequiresWriterPermission = types.BoolValue(f.CopyRequiresWriterPermission)
+	}
+}
+
+func (fileCopyModel *gdriveFileCopyResourceModel) populate(f *drive.File) {
+	fileCopyModel.Id = types.StringValue(f.Id)
+	fileCopyModel.FileId = fileCopyModel.Id
+	fileCopyModel.Name = types.StringValue(f.Name)
+	fileCopyModel.MimeType = types.StringValue(f.MimeType)
+	if len(f.Parents) != 0 {
+		fileCopyModel.Parent = types.StringValue(f.Parents[0])
+	}
+	fileCopyModel.DriveId = stringValueOrNull(f.DriveId)
+}
+
+// escapeQuery escapes a value for a single quoted string in a Drive query.
+func escapeQuery(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+// resolvePath returns the file at filePath, a slash separated path of file names relative to the root of
+// the Shared Drive driveID or, if driveID is empty, the root of the user's My Drive.
+func resolvePath(ctx context.Context, client *gdriveClient, driveID, filePath, fields string) (file *drive.File, diags diag.Diagnostics) {
+	names := []string{}
+	for _, name := range strings.Split(filePath, "/") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		diags.AddError("Invalid Path", fmt.Sprintf("The path %q does not contain any file names", filePath))
+		return
+	}
+	corpora := "user"
+	parentID := "root"
+	if driveID != "" {
+		corpora = "drive"
+		parentID = driveID
+	}
+	for i, name := range names {
+		q := fmt.Sprintf("name = '%s' and '%s' in parents and trashed = false", escapeQuery(name), escapeQuery(parentID))
+		if i < len(names)-1 {
+			q += fmt.Sprintf(" and mimeType = '%s'", mimeTypeFolder)
+		}
+		files, err := client.listFiles(ctx, q, driveID, corpora, "", fmt.Sprintf("files(%s),nextPageToken", fields), driveID != "")
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to list files, got error: %s", err))
+			return
+		}
+		current := strings.Join(names[:i+1], "/")
+		switch len(files) {
+		case 0:
+			diags.AddError("File Not Found", fmt.Sprintf("Unable to find %q: no file or folder named %q exists in its parent folder", filePath, current))
+			return
+		case 1:
+			file = files[0]
+			parentID = file.Id
+		default:
+			ids := make([]string, len(files))
+			for j := range files {
+				ids[j] = files[j].Id
+			}
+			diags.AddError("Ambiguous Path", fmt.Sprintf("Unable to resolve %q: %d files or folders named %q exist in the same parent folder (IDs: %s). Rename the duplicates or use the gdrive_file data source with a file_id instead.", filePath, len(files), current, strings.Join(ids, ", ")))
+			return
+		}
+	}
+	return file, diags
+}
+
+// fileUserModel describes a user in the metadata of a file.
+type fileUserModel struct {
+	DisplayName  types.String `tfsdk:"display_name"`
+	EmailAddress types.String `tfsdk:"email_address"`
+}
+
+// fileContentRestrictionModel describes a restriction for accessing the content of a file.
+type fileContentRestrictionModel struct {
+	ReadOnly        types.Bool   `tfsdk:"read_only"`
+	Reason          types.String `tfsdk:"reason"`
+	Type            types.String `tfsdk:"type"`
+	RestrictingUser types.String `tfsdk:"restricting_user"`
+	RestrictionTime types.String `tfsdk:"restriction_time"`
+}
+
+func dsFileUser(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"display_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "A plain text displayable name for this user.",
+			},
+			"email_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The email address of the user.",
+			},
+		},
+	}
+}
+
+// dsFileMetadata returns the read-only metadata attributes that are shared by the gdrive_file, gdrive_files and gdrive_file_by_path data sources.
+func dsFileMetadata() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"size": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Size in bytes of the file. Not present for Google Docs, Sheets, folders, etc.",
+		},
+		"md5_checksum": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The MD5 checksum of the file's content. Not present for Google Docs, Sheets, folders, etc.",
+		},
+		"created_time": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The time at which the file was created (RFC 3339 date-time).",
+		},
+		"modified_time": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The last time the file was modified by anyone (RFC 3339 date-time).",
+		},
+		"owners": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The owner of the file. Not present for files in Shared Drives.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: dsFileUser("").Attributes,
+			},
+		},
+		"last_modifying_user": dsFileUser("The last user to modify the file."),
+		"web_view_link": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "A link for opening the file in a relevant Google editor or viewer in a browser.",
+		},
+		"web_content_link": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "A link for downloading the content of the file in a browser. Not present for Google Docs, Sheets, folders, etc.",
+		},
+		"icon_link": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "A static, unauthenticated link to the file's icon.",
+		},
+		"trashed": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether the file has been trashed.",
+		},
+		"capabilities": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.BoolType,
+			MarkdownDescription: `The capabilities the current user has on the file, e.g. "can_edit", "can_delete" or "can_share".
+
+See https://developers.google.com/drive/api/reference/rest/v3/files#File for a list of capabilities.`,
+		},
+		"content_restrictions": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "Restrictions for accessing the content of the file.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"read_only": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the content of the file is read-only.",
+					},
+					"reason": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Reason for why the content of the file is restricted.",
+					},
+					"type": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The type of the content restriction.",
+					},
+					"restricting_user": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The email address of the user who set the content restriction.",
+					},
+					"restriction_time": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The time at which the content restriction was set (RFC 3339 date-time).",
+					},
+				},
+			},
+		},
+		"export_links": schema.MapAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Links for exporting Google Docs, Sheets, etc. to specific formats, keyed by MIME type.",
+		},
+	}
+}
+
+func fileUser(u *drive.User) *fileUserModel {
+	if u == nil {
+		return nil
+	}
+	return &fileUserModel{
+		DisplayName:  stringValueOrNull(u.DisplayName),
+		EmailAddress: stringValueOrNull(u.EmailAddress),
+	}
+}
+
+func fileOwners(f *drive.File) []*fileUserModel {
+	owners := []*fileUserModel{}
+	for i := range f.Owners {
+		owners = append(owners, fileUser(f.Owners[i]))
+	}
+	return owners
+}
+
+func fileSize(f *drive.File) types.Int64 {
+	if isGoogleWorkspaceType(f.MimeType) {
+		return types.Int64Null()
+	}
+	return types.Int64Value(f.Size)
+}
+
+func fileContentRestrictions(f *drive.File) []*fileContentRestrictionModel {
+	restrictions := []*fileContentRestrictionModel{}
+	for _, r := range f.ContentRestrictions {
+		restriction := &fileContentRestrictionModel{
+			ReadOnly:        types.BoolValue(r.ReadOnly),
+			Reason:          stringValueOrNull(r.Reason),
+			Type:            stringValueOrNull(r.Type),
+			RestrictionTime: stringValueOrNull(r.RestrictionTime),
+		}
+		if r.RestrictingUser != nil {
+			restriction.RestrictingUser = stringValueOrNull(r.RestrictingUser.EmailAddress)
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions
+}
+
+// fileCapabilities returns the capabilities of f, keyed by their names in snake_case.
+// The capabilities are listed explicitly, so that upgrading the Drive API client does not change the keys.
+// Deprecated capabilities (e.g., the Team Drive variants) are left out.
+func fileCapabilities(f *drive.File) (types.Map, diag.Diagnostics) {
+	elements := map[string]attr.Value{}
+	if f.Capabilities == nil {
+		return types.MapValue(types.BoolType, elements)
+	}
+	c := f.Capabilities
+	capabilities := map[string]bool{
+		"can_accept_ownership":                       c.CanAcceptOwnership,
+		"can_add_children":                           c.CanAddChildren,
+		"can_add_folder_from_another_drive":          c.CanAddFolderFromAnotherDrive,
+		"can_add_my_drive_parent":                    c.CanAddMyDriveParent,
+		"can_change_copy_requires_writer_permission": c.CanChangeCopyRequiresWriterPermission,
+		"can_change_security_update_enabled":         c.CanChangeSecurityUpdateEnabled,
+		"can_comment":                                c.CanComment,
+		"can_copy":                                   c.CanCopy,
+		"can_delete":                                 c.CanDelete,
+		"can_delete_children":                        c.CanDeleteChildren,
+		"can_disable_inherited_permissions":          c.CanDisableInheritedPermissions,
+		"can_download":                               c.CanDownload,
+		"can_edit":                                   c.CanEdit,
+		"can_enable_inherited_permissions":           c.CanEnableInheritedPermissions,
+		"can_list_children":                          c.CanListChildren,
+		"can_modify_content":                         c.CanModifyContent,
+		"can_modify_editor_content_restriction":      c.CanModifyEditorContentRestriction,
+		"can_modify_labels":                          c.CanModifyLabels,
+		"can_modify_owner_content_restriction":       c.CanModifyOwnerContentRestriction,
+		"can_move_children_out_of_drive":             c.CanMoveChildrenOutOfDrive,
+		"can_move_children_within_drive":             c.CanMoveChildrenWithinDrive,
+		"can_move_item_out_of_drive":                 c.CanMoveItemOutOfDrive,
+		"can_move_item_within_drive":                 c.CanMoveItemWithinDrive,
+		"can_read_drive":                             c.CanReadDrive,
+		"can_read_labels":                            c.CanReadLabels,
+		"can_read_revisions":                         c.CanReadRevisions,
+		"can_remove_children":                        c.CanRemoveChildren,
+		"can_remove_content_restriction":             c.CanRemoveContentRestriction,
+		"can_remove_my_drive_parent":                 c.CanRemoveMyDriveParent,
+		"can_rename":                                 c.CanRename,
+		"can_share":                                  c.CanShare,
+		"can_trash":                                  c.CanTrash,
+		"can_trash_children":                         c.CanTrashChildren,
+		"can_untrash":                                c.CanUntrash,
+	}
+	for k, v := range capabilities {
+		elements[k] = types.BoolValue(v)
+	}
+	return types.MapValue(types.BoolType, elements)
+}
+
+// fileMetadataModel describes the read-only metadata attributes that are shared by the file data sources (see dsFileMetadata).
+type fileMetadataModel struct {
+	Size                types.Int64                    `tfsdk:"size"`
+	Md5Checksum         types.String                   `tfsdk:"md5_checksum"`
+	CreatedTime         types.String                   `tfsdk:"created_time"`
+	ModifiedTime        types.String                   `tfsdk:"modified_time"`
+	Owners              []*fileUserModel               `tfsdk:"owners"`
+	LastModifyingUser   *fileUserModel                 `tfsdk:"last_modifying_user"`
+	WebViewLink         types.String                   `tfsdk:"web_view_link"`
+	WebContentLink      types.String                   `tfsdk:"web_content_link"`
+	IconLink            types.String                   `tfsdk:"icon_link"`
+	Trashed             types.Bool                     `tfsdk:"trashed"`
+	Capabilities        types.Map                      `tfsdk:"capabilities"`
+	ContentRestrictions []*fileContentRestrictionModel `tfsdk:"content_restrictions"`
+	ExportLinks         types.Map                      `tfsdk:"export_links"`
+}
+
+// populateMetadata sets the read-only metadata attributes of metadataModel from f.
+func (metadataModel *fileMetadataModel) populateMetadata(f *drive.File) (diags diag.Diagnostics) {
+	metadataModel.Size = fileSize(f)
+	metadataModel.Md5Checksum = stringValueOrNull(f.Md5Checksum)
+	metadataModel.CreatedTime = stringValueOrNull(f.CreatedTime)
+	metadataModel.ModifiedTime = stringValueOrNull(f.ModifiedTime)
+	metadataModel.Owners = fileOwners(f)
+	metadataModel.LastModifyingUser = fileUser(f.LastModifyingUser)
+	metadataModel.WebViewLink = stringValueOrNull(f.WebViewLink)
+	metadataModel.WebContentLink = stringValueOrNull(f.WebContentLink)
+	metadataModel.IconLink = stringValueOrNull(f.IconLink)
+	metadataModel.Trashed = types.BoolValue(f.Trashed)
+	metadataModel.ContentRestrictions = fileContentRestrictions(f)
+	var d diag.Diagnostics
+	metadataModel.Capabilities, d = fileCapabilities(f)
+	diags.Append(d...)
+	metadataModel.ExportLinks, d = stringMapValue(f.ExportLinks, types.MapNull(types.StringType))
+	diags.Append(d...)
+	return diags
+}
@@ -0,0 +1,390 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+const fieldsFolderSyncTree = "files(id,name,mimeType,md5Checksum),nextPageToken"
+
+// syncedFileModel describes an entry of the files attribute of gdrive_folder_sync.
+type syncedFileModel struct {
+	FileId      types.String `tfsdk:"file_id"`
+	Md5Checksum types.String `tfsdk:"md5_checksum"`
+}
+
+var syncedFileType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"file_id":      types.StringType,
+		"md5_checksum": types.StringType,
+	},
+}
+
+// localTree describes the content of the local source directory.
+// All paths are relative to the source directory and use forward slashes.
+type localTree struct {
+	folders []string
+	// files maps the path of each file to its MD5 hash.
+	files map[string]string
+}
+
+// readLocalTree walks dir and hashes all files in it.
+func readLocalTree(dir string) (*localTree, error) {
+	tree := &localTree{
+		folders: []string{},
+		files:   map[string]string{},
+	}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			tree.folders = append(tree.folders, rel)
+			return nil
+		}
+		// Follow symlinks to files, skip everything else that is not a regular file.
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		hash, err := md5Hash(f)
+		if err != nil {
+			return err
+		}
+		tree.files[rel] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(tree.folders)
+	return tree, nil
+}
+
+// parentPath returns the path of the parent folder of p, which is "" for the synced folder itself.
+func parentPath(p string) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// listTree returns all files and folders below folderID, keyed by their path relative to folderID.
+// Drive allows several files with the same name in a folder. Only the first one is part of the tree,
+// the others are returned as duplicates.
+func listTree(ctx context.Context, client *gdriveClient, folderID string) (map[string]*drive.File, []*drive.File, error) {
+	tree := map[string]*drive.File{}
+	duplicates := []*drive.File{}
+	folderIDs := map[string]string{"": folderID}
+	queue := []string{""}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		files, err := client.listFiles(ctx, fmt.Sprintf("'%s' in parents and trashed = false", folderIDs[dir]), "", "allDrives", "", fieldsFolderSyncTree, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range files {
+			p := path.Join(dir, f.Name)
+			if _, ok := tree[p]; ok {
+				duplicates = append(duplicates, f)
+				continue
+			}
+			tree[p] = f
+			if f.MimeType == mimeTypeFolder {
+				folderIDs[p] = f.Id
+				queue = append(queue, p)
+			}
+		}
+	}
+	return tree, duplicates, nil
+}
+
+// removeFile deletes a file, if behavior is "delete", and moves it to the trash otherwise.
+func removeFile(ctx context.Context, client *gdriveClient, fileID, behavior string) error {
+	if behavior == deleteBehaviorDelete {
+		return client.deleteFile(ctx, fileID)
+	}
+	return client.trashFile(ctx, fileID)
+}
+
+// hasAncestor reports whether one of the ancestors of p is in paths.
+func hasAncestor(p string, paths map[string]bool) bool {
+	for dir := parentPath(p); dir != ""; dir = parentPath(dir) {
+		if paths[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// sync mirrors the local source directory into the Drive folder and sets the files and folders
+// attributes to the result.
+func (syncModel *gdriveFolderSyncResourceModel) sync(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
+	sourceDir := syncModel.SourceDir.ValueString()
+	local, err := readLocalTree(sourceDir)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read local directory (source_dir), got error: %s", err))
+		return
+	}
+	remote, duplicates, err := listTree(ctx, client, syncModel.FolderId.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list files of folder, got error: %s", err))
+		return
+	}
+	folders := map[string]string{}
+	folderIDs := map[string]string{"": syncModel.FolderId.ValueString()}
+	for _, p := range local.folders {
+		if f, ok := remote[p]; ok {
+			if f.MimeType != mimeTypeFolder {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to create folder %s, because a file with the same name exists in Drive", p))
+				return
+			}
+			folderIDs[p] = f.Id
+			folders[p] = f.Id
+			continue
+		}
+		f, err := client.createFile(ctx, &drive.File{
+			Name:     path.Base(p),
+			MimeType: mimeTypeFolder,
+			Parents:  []string{folderIDs[parentPath(p)]},
+		}, nil, "", "id", 0)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to create folder %s, got error: %s", p, err))
+			return
+		}
+		tflog.Debug(ctx, "Created folder", map[string]interface{}{"path": p, "file_id": f.Id})
+		folderIDs[p] = f.Id
+		folders[p] = f.Id
+	}
+	filePaths := make([]string, 0, len(local.files))
+	for p := range local.files {
+		filePaths = append(filePaths, p)
+	}
+	sort.Strings(filePaths)
+	files := map[string]syncedFileModel{}
+	for _, p := range filePaths {
+		hash := local.files[p]
+		existing, ok := remote[p]
+		if ok && existing.MimeType == mimeTypeFolder {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to upload file %s, because a folder with the same name exists in Drive", p))
+			return
+		}
+		if ok && existing.Md5Checksum == hash {
+			files[p] = syncedFileModel{
+				FileId:      types.StringValue(existing.Id),
+				Md5Checksum: types.StringValue(hash),
+			}
+			continue
+		}
+		f, err := syncModel.upload(ctx, client, p, existing, folderIDs[parentPath(p)])
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to upload file %s, got error: %s", p, err))
+			return
+		}
+		files[p] = syncedFileModel{
+			FileId:      types.StringValue(f.Id),
+			Md5Checksum: stringValueOrNull(f.Md5Checksum),
+		}
+	}
+	if syncModel.DeleteOrphans.ValueBool() {
+		orphans := map[string]bool{}
+		for p := range remote {
+			_, isFile := local.files[p]
+			_, isFolder := folders[p]
+			if !isFile && !isFolder {
+				orphans[p] = true
+			}
+		}
+		orphanPaths := make([]string, 0, len(orphans))
+		for p := range orphans {
+			// The descendants of a folder are removed together with the folder.
+			if !hasAncestor(p, orphans) {
+				orphanPaths = append(orphanPaths, p)
+			}
+		}
+		sort.Strings(orphanPaths)
+		for _, p := range orphanPaths {
+			err := removeFile(ctx, client, remote[p].Id, syncModel.OrphanBehavior.ValueString())
+			if err != nil && !isNotFound(err) {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to remove orphan %s, got error: %s", p, err))
+				return
+			}
+			tflog.Debug(ctx, "Removed orphan", map[string]interface{}{"path": p, "file_id": remote[p].Id})
+		}
+		for _, f := range duplicates {
+			err := removeFile(ctx, client, f.Id, syncModel.OrphanBehavior.ValueString())
+			if err != nil && !isNotFound(err) {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to remove duplicate %s, got error: %s", f.Name, err))
+				return
+			}
+		}
+	}
+	diags.Append(syncModel.setManifest(ctx, files, folders)...)
+	return diags
+}
+
+// upload uploads the local file at p. If existing is not nil, a new version of it is uploaded.
+func (syncModel *gdriveFolderSyncResourceModel) upload(ctx context.Context, client *gdriveClient, p string, existing *drive.File, parentID string) (*drive.File, error) {
+	content, err := os.Open(filepath.Join(syncModel.SourceDir.ValueString(), filepath.FromSlash(p)))
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	fileReq := &drive.File{
+		Name: path.Base(p),
+	}
+	if existing != nil {
+		tflog.Debug(ctx, "Uploading changed file", map[string]interface{}{"path": p, "file_id": existing.Id})
+		return client.updateFile(ctx, existing.Id, "", "", "id,md5Checksum", fileReq, content, googleapi.DefaultUploadChunkSize)
+	}
+	tflog.Debug(ctx, "Uploading new file", map[string]interface{}{"path": p})
+	fileReq.Parents = []string{parentID}
+	return client.createFile(ctx, fileReq, content, "", "id,md5Checksum", googleapi.DefaultUploadChunkSize)
+}
+
+// setManifest sets the files and folders attributes.
+func (syncModel *gdriveFolderSyncResourceModel) setManifest(ctx context.Context, files map[string]syncedFileModel, folders map[string]string) (diags diag.Diagnostics) {
+	var d diag.Diagnostics
+	syncModel.Files, d = types.MapValueFrom(ctx, syncedFileType, files)
+	diags.Append(d...)
+	syncModel.Folders, d = types.MapValueFrom(ctx, types.StringType, folders)
+	diags.Append(d...)
+	return diags
+}
+
+// refresh updates the files and folders attributes from the content of the Drive folder.
+// Entries that no longer exist are removed. If delete_orphans is set, files and folders that are
+// not part of the state are added, so that the next plan removes them.
+// After an import, the files and folders attributes are null and all content of the Drive folder is added.
+func (syncModel *gdriveFolderSyncResourceModel) refresh(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
+	imported := syncModel.Files.IsNull() && syncModel.Folders.IsNull()
+	remote, _, err := listTree(ctx, client, syncModel.FolderId.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list files of folder, got error: %s", err))
+		return
+	}
+	stateFiles := map[string]syncedFileModel{}
+	if !syncModel.Files.IsNull() {
+		diags.Append(syncModel.Files.ElementsAs(ctx, &stateFiles, false)...)
+	}
+	stateFolders := map[string]string{}
+	if !syncModel.Folders.IsNull() {
+		diags.Append(syncModel.Folders.ElementsAs(ctx, &stateFolders, false)...)
+	}
+	if diags.HasError() {
+		return
+	}
+	files := map[string]syncedFileModel{}
+	folders := map[string]string{}
+	for p, f := range remote {
+		_, isStateFile := stateFiles[p]
+		_, isStateFolder := stateFolders[p]
+		if !isStateFile && !isStateFolder && !syncModel.DeleteOrphans.ValueBool() && !imported {
+			continue
+		}
+		if f.MimeType == mimeTypeFolder {
+			folders[p] = f.Id
+		} else {
+			files[p] = syncedFileModel{
+				FileId:      types.StringValue(f.Id),
+				Md5Checksum: stringValueOrNull(f.Md5Checksum),
+			}
+		}
+	}
+	diags.Append(syncModel.setManifest(ctx, files, folders)...)
+	return diags
+}
+
+// setDefaults sets the attributes that cannot be read from the API to their defaults if they are null,
+// which is the case after an import.
+func (syncModel *gdriveFolderSyncResourceModel) setDefaults() {
+	if syncModel.DeleteOrphans.IsNull() {
+		syncModel.DeleteOrphans = types.BoolValue(false)
+	}
+	if syncModel.OrphanBehavior.IsNull() {
+		syncModel.OrphanBehavior = types.StringValue(deleteBehaviorTrash)
+	}
+	if syncModel.DeleteBehavior.IsNull() {
+		syncModel.DeleteBehavior = types.StringValue(deleteBehaviorTrash)
+	}
+	if syncModel.DeletionProtection.IsNull() {
+		syncModel.DeletionProtection = types.BoolValue(true)
+	}
+}
+
+// inSync reports whether the files and folders attributes match the local tree.
+func (syncModel *gdriveFolderSyncResourceModel) inSync(ctx context.Context, local *localTree) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if syncModel.Files.IsNull() || syncModel.Files.IsUnknown() || syncModel.Folders.IsNull() || syncModel.Folders.IsUnknown() {
+		return false, diags
+	}
+	files := map[string]syncedFileModel{}
+	diags.Append(syncModel.Files.ElementsAs(ctx, &files, false)...)
+	folders := map[string]string{}
+	diags.Append(syncModel.Folders.ElementsAs(ctx, &folders, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	if len(files) != len(local.files) || len(folders) != len(local.folders) {
+		return false, diags
+	}
+	for p, hash := range local.files {
+		f, ok := files[p]
+		if !ok || f.Md5Checksum.ValueString() != hash {
+			return false, diags
+		}
+	}
+	for _, p := range local.folders {
+		if _, ok := folders[p]; !ok {
+			return false, diags
+		}
+	}
+	return true, diags
+}
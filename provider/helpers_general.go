@@ -23,21 +23,36 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"google.golang.org/api/drive/v3"
 
-	"github.com/hanneshayashi/gsm/gsmcibeta"
 	cibeta "google.golang.org/api/cloudidentity/v1beta1"
 )
 
+// defaultTimeout is used for all operations of a resource, unless a timeout is configured in the timeouts block.
+const defaultTimeout = 20 * time.Minute
+
+// Values of delete_behavior.
+const (
+	deleteBehaviorTrash   = "trash"
+	deleteBehaviorDelete  = "delete"
+	deleteBehaviorAbandon = "abandon"
+)
+
 func combineId(a, b string) string {
 	return fmt.Sprintf("%s/%s", a, b)
 }
@@ -51,19 +66,36 @@ func splitId(id string) (string, string, error) {
 }
 
 func (fileModel *gdriveFileResourceModel) toRequest() *drive.File {
-	return &drive.File{
-		MimeType: fileModel.MimeType.ValueString(),
-		Name:     fileModel.Name.ValueString(),
-		DriveId:  fileModel.DriveId.ValueString(),
+	file := &drive.File{
+		MimeType:       fileModel.MimeType.ValueString(),
+		Name:           fileModel.Name.ValueString(),
+		DriveId:        fileModel.DriveId.ValueString(),
+		Description:    fileModel.Description.ValueString(),
+		Properties:     stringMap(fileModel.Properties),
+		AppProperties:  stringMap(fileModel.AppProperties),
+		FolderColorRgb: fileModel.FolderColorRgb.ValueString(),
+	}
+	if !fileModel.Starred.IsNull() && !fileModel.Starred.IsUnknown() {
+		file.Starred = fileModel.Starred.ValueBool()
+		file.ForceSendFields = append(file.ForceSendFields, "Starred")
 	}
+	if !fileModel.WritersCanShare.IsNull() && !fileModel.WritersCanShare.IsUnknown() {
+		file.WritersCanShare = fileModel.WritersCanShare.ValueBool()
+		file.ForceSendFields = append(file.ForceSendFields, "WritersCanShare")
+	}
+	if !fileModel.CopyRequiresWriterPermission.IsNull() && !fileModel.CopyRequiresWriterPermission.IsUnknown() {
+		file.CopyRequiresWriterPermission = fileModel.CopyRequiresWriterPermission.ValueBool()
+		file.ForceSendFields = append(file.ForceSendFields, "CopyRequiresWriterPermission")
+	}
+	return file
 }
 
-func (membershipModel *gdriveOrgUnitMembershipResourceModel) move() (diags diag.Diagnostics) {
+func (membershipModel *gdriveOrgUnitMembershipResourceModel) move(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
 	moveOrgMembershipRequest := &cibeta.MoveOrgMembershipRequest{
 		Customer:           "customers/my_customer",
 		DestinationOrgUnit: "orgUnits/" + membershipModel.Parent.ValueString(),
 	}
-	membership, err := gsmcibeta.MoveOrgUnitMemberships("orgUnits/-/memberships/shared_drive;"+membershipModel.DriveId.ValueString(), "", moveOrgMembershipRequest)
+	membership, err := client.moveOrgUnitMemberships(ctx, "orgUnits/-/memberships/shared_drive;"+membershipModel.DriveId.ValueString(), "", moveOrgMembershipRequest)
 	if err != nil {
 		diags.AddError("Client Error", fmt.Sprintf("Unable to move Shared Drive to Org Unit, got error: %s", err))
 		return
@@ -98,6 +130,131 @@ func rsId() rsschema.StringAttribute {
 	return id
 }
 
+func rsImpersonateSubject() rsschema.StringAttribute {
+	return rsschema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: `The email address of a Workspace user the provider should impersonate with Domain Wide Delegation (DWD) to manage this resource.
+Use this to manage content in the user's My Drive or to write as that user. If unset, the provider's subject is used.`,
+	}
+}
+
+// rsDeleteBehavior returns the delete_behavior attribute, which defaults to def and accepts the given values.
+func rsDeleteBehavior(description, def string, values ...string) rsschema.StringAttribute {
+	return rsschema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(def),
+		MarkdownDescription: description,
+		Validators: []validator.String{
+			stringvalidator.OneOf(values...),
+		},
+	}
+}
+
+func rsDeletionProtection() rsschema.BoolAttribute {
+	return rsschema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		MarkdownDescription: `If set to true, Terraform refuses to destroy (or replace) this resource.
+Set it to false and apply the change before destroying the resource.`,
+	}
+}
+
+// checkDeletionProtection returns an error if deletion_protection is enabled.
+func checkDeletionProtection(deletionProtection types.Bool, id types.String) (diags diag.Diagnostics) {
+	if deletionProtection.ValueBool() {
+		diags.AddError("Deletion Protection", fmt.Sprintf("Unable to delete %s, because deletion_protection is set to true. Set it to false and apply the change before destroying the resource.", id.ValueString()))
+	}
+	return diags
+}
+
+// setDeleteDefaults sets delete_behavior and deletion_protection to their defaults if they are null,
+// which is the case after an import.
+func setDeleteDefaults(deleteBehavior *types.String, deletionProtection *types.Bool) {
+	if deleteBehavior.IsNull() {
+		*deleteBehavior = types.StringValue(deleteBehaviorDelete)
+	}
+	if deletionProtection.IsNull() {
+		*deletionProtection = types.BoolValue(false)
+	}
+}
+
+// abandon logs that a resource is only removed from the state, because delete_behavior is "abandon".
+func abandon(ctx context.Context, id types.String) {
+	tflog.Warn(ctx, "delete_behavior is abandon, removing the resource from the state without deleting it", map[string]interface{}{
+		"id": id.ValueString(),
+	})
+}
+
+// impersonate returns the client that acts as subject or the provider's client, if subject is not set.
+func impersonate(ctx context.Context, client *gdriveClient, subject types.String) (*gdriveClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	subjectClient, err := client.forSubject(ctx, subject.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to impersonate %s, got error: %s", subject.ValueString(), err))
+	}
+	return subjectClient, diags
+}
+
+// timeoutFunc returns the configured timeout of an operation, e.g. timeouts.Value.Create.
+type timeoutFunc func(ctx context.Context, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics)
+
+// withTimeout returns a context that is cancelled after the timeout returned by timeout.
+// The returned cancel function is never nil and must be called, even if diags contain an error.
+func withTimeout(ctx context.Context, timeout timeoutFunc) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	t, diags := timeout(ctx, defaultTimeout)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, t)
+	return ctx, cancel, diags
+}
+
+// withTimeoutAndClient combines withTimeout and impersonate, which every resource operation does before calling the API.
+// The returned cancel function is never nil and must be called, even if diags contain an error.
+func withTimeoutAndClient(ctx context.Context, timeout timeoutFunc, client *gdriveClient, subject types.String) (context.Context, context.CancelFunc, *gdriveClient, diag.Diagnostics) {
+	ctx, cancel, diags := withTimeout(ctx, timeout)
+	if diags.HasError() {
+		return ctx, cancel, nil, diags
+	}
+	subjectClient, impersonateDiags := impersonate(ctx, client, subject)
+	diags.Append(impersonateDiags...)
+	return ctx, cancel, subjectClient, diags
+}
+
+// notFoundDiagnostic is an error diagnostic for an object that does not exist (anymore).
+// Read uses it to remove the resource from the state instead of failing.
+type notFoundDiagnostic struct {
+	diag.ErrorDiagnostic
+}
+
+// clientErrorDiagnostic returns a "Client Error" diagnostic for err, which is a notFoundDiagnostic if err indicates that an object does not exist.
+func clientErrorDiagnostic(msg string, err error) diag.Diagnostic {
+	d := diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("%s, got error: %s", msg, err))
+	if isNotFound(err) {
+		return notFoundDiagnostic{d}
+	}
+	return d
+}
+
+// hasNotFound returns true if diags contain a notFoundDiagnostic.
+func hasNotFound(diags diag.Diagnostics) bool {
+	for i := range diags {
+		if _, ok := diags[i].(notFoundDiagnostic); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFromState removes a resource that was deleted outside of Terraform from the state,
+// so that Terraform plans to create it again.
+func removeFromState(ctx context.Context, resp *resource.ReadResponse) {
+	tflog.Warn(ctx, "Resource not found, removing it from the state")
+	resp.State.RemoveResource(ctx)
+}
+
 func importSplitId(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, adminAttribute, idAttribute string) (diags diag.Diagnostics) {
 	idParts := strings.Split(req.ID, ",")
 	if len(idParts) < 2 {
@@ -119,3 +276,32 @@ func importSplitId(ctx context.Context, req resource.ImportStateRequest, resp *r
 	diags.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(idParts[1:], ""))...)
 	return diags
 }
+
+// positiveDurationValidator checks that a string is a duration in Go's duration format that is greater than zero.
+type positiveDurationValidator struct{}
+
+func positiveDuration() validator.String {
+	return positiveDurationValidator{}
+}
+
+func (v positiveDurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, e.g. \"500ms\", \"30s\" or \"10m\""
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", fmt.Sprintf("Unable to parse %q as a duration, got error: %s", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", fmt.Sprintf("The duration must be greater than zero, got: %s", req.ConfigValue.ValueString()))
+	}
+}
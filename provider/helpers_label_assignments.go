@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -105,7 +104,7 @@ func (fieldModel *gdriveLabelFieldModel) toFieldModification(ctx context.Context
 	return fieldMod, diags
 }
 
-func setFieldDiffs(plan, state *gdriveLabelAssignmentResourceModel, ctx context.Context) (diags diag.Diagnostics) {
+func setFieldDiffs(client *gdriveClient, plan, state *gdriveLabelAssignmentResourceModel, ctx context.Context) (diags diag.Diagnostics) {
 	modLabelsReq := &drive.ModifyLabelsRequest{
 		LabelModifications: []*drive.LabelModification{
 			{
@@ -136,7 +135,7 @@ func setFieldDiffs(plan, state *gdriveLabelAssignmentResourceModel, ctx context.
 			})
 		}
 	}
-	_, err := gsmdrive.ModifyLabels(plan.FileId.ValueString(), "", modLabelsReq)
+	_, err := client.modifyLabels(ctx, plan.FileId.ValueString(), "", modLabelsReq)
 	if err != nil {
 		diags.AddError("Configuration Error", fmt.Sprintf("Unable to update label assignment, got error: %s", err))
 		return
@@ -144,7 +143,7 @@ func setFieldDiffs(plan, state *gdriveLabelAssignmentResourceModel, ctx context.
 	return
 }
 
-func setLabelDiffs(plan, state *gdriveLabelPolicyResourceModel, ctx context.Context) (diags diag.Diagnostics) {
+func setLabelDiffs(client *gdriveClient, plan, state *gdriveLabelPolicyResourceModel, ctx context.Context) (diags diag.Diagnostics) {
 	planLabels := plan.toMap()
 	stateLabels := state.toMap()
 	modLabelsReq := &drive.ModifyLabelsRequest{
@@ -206,7 +205,7 @@ func setLabelDiffs(plan, state *gdriveLabelPolicyResourceModel, ctx context.Cont
 			})
 		}
 	}
-	_, err := gsmdrive.ModifyLabels(plan.FileId.ValueString(), "", modLabelsReq)
+	_, err := client.modifyLabels(ctx, plan.FileId.ValueString(), "", modLabelsReq)
 	if err != nil {
 		diags.AddError("Configuration Error", fmt.Sprintf("Unable to update label assignment, got error: %s", err))
 		return diags
@@ -257,15 +256,19 @@ func driveLabelFieldToFieldModel(field drive.LabelField, ctx context.Context) (f
 	return fieldModel, diags
 }
 
-func (labelAssignmentModel *gdriveLabelAssignmentResourceModel) populate(ctx context.Context) (diags diag.Diagnostics) {
+func (labelAssignmentModel *gdriveLabelAssignmentResourceModel) populate(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
 	fileID, labelID, e := splitId(labelAssignmentModel.Id.ValueString())
 	if e != nil {
 		diags.AddError("Config Error", fmt.Sprintf("Unable to use ID, got error: %s", e))
 		return diags
 	}
 	labelAssignmentModel.Fields = []*gdriveLabelFieldModel{}
-	currentLabels, err := gsmdrive.ListLabels(fileID, "", 1)
-	for l := range currentLabels {
+	currentLabels, err := client.listLabels(ctx, fileID, "")
+	if err != nil {
+		diags.Append(clientErrorDiagnostic("Unable to list labels on file", err))
+		return diags
+	}
+	for _, l := range currentLabels {
 		if l.Id == labelID {
 			for f := range l.Fields {
 				var field *gdriveLabelFieldModel
@@ -277,23 +280,22 @@ func (labelAssignmentModel *gdriveLabelAssignmentResourceModel) populate(ctx con
 			}
 		}
 	}
-	e = <-err
-	if e != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to list labels on file, got error: %s", e))
-		return diags
-	}
 	labelAssignmentModel.FileId = types.StringValue(fileID)
 	labelAssignmentModel.LabelId = types.StringValue(labelID)
 	return diags
 }
 
-func (labelPolicyModel *gdriveLabelPolicyResourceModel) populate(ctx context.Context) diag.Diagnostics {
+func (labelPolicyModel *gdriveLabelPolicyResourceModel) populate(ctx context.Context, client *gdriveClient) diag.Diagnostics {
 	var diags diag.Diagnostics
 	labelPolicyModel.Labels = []*gdriveLabelPolicyLabelModel{}
 	labelPolicyModel.FileId = labelPolicyModel.Id
 	fileID := labelPolicyModel.FileId.ValueString()
-	currentLabels, err := gsmdrive.ListLabels(fileID, "", 1)
-	for l := range currentLabels {
+	currentLabels, err := client.listLabels(ctx, fileID, "")
+	if err != nil {
+		diags.Append(clientErrorDiagnostic("Unable to list labels on file", err))
+		return diags
+	}
+	for _, l := range currentLabels {
 		label := &gdriveLabelPolicyLabelModel{
 			LabelId: types.StringValue(l.Id),
 			Fields:  []*gdriveLabelFieldModel{},
@@ -342,11 +344,6 @@ func (labelPolicyModel *gdriveLabelPolicyResourceModel) populate(ctx context.Con
 		}
 		labelPolicyModel.Labels = append(labelPolicyModel.Labels, label)
 	}
-	e := <-err
-	if e != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to list labels on file, got error: %s", e))
-		return diags
-	}
 	return diags
 }
 
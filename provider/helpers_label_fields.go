@@ -18,10 +18,10 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package provider
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -61,12 +61,12 @@ func (badgeConfigModel *gdriveLabelChoiceBadgeConfigModel) populate(badgeConfig
 	}
 }
 
-func populateField(fieldModel fieldInterface) (field *drivelabels.GoogleAppsDriveLabelsV2Field, err error) {
+func populateField(ctx context.Context, client *gdriveClient, fieldModel fieldInterface) (field *drivelabels.GoogleAppsDriveLabelsV2Field, err error) {
 	labelId, fieldId, err := splitId(fieldModel.getId())
 	if err != nil {
 		return nil, err
 	}
-	l, err := gsmdrivelabels.GetLabel(gsmhelpers.EnsurePrefix(labelId, "labels/"), fieldModel.getLanguageCode(), "LABEL_VIEW_FULL", "*", fieldModel.getUseAdminAccess())
+	l, err := client.getLabel(ctx, gsmhelpers.EnsurePrefix(labelId, "labels/"), fieldModel.getLanguageCode(), "LABEL_VIEW_FULL", "*", fieldModel.getUseAdminAccess())
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +89,7 @@ func populateField(fieldModel fieldInterface) (field *drivelabels.GoogleAppsDriv
 			return l.Fields[i], nil
 		}
 	}
-	return nil, fmt.Errorf("field not found")
+	return nil, fmt.Errorf("field %s %w", fieldId, errNotFound)
 }
 
 func getUpdateFieldLifecycleRequest(id string, lifecycle *drivelabels.GoogleAppsDriveLabelsV2Lifecycle) (request *drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelRequestRequest) {
@@ -131,7 +131,7 @@ func newUpdateFieldRequest(plan, state fieldInterface) *drivelabels.GoogleAppsDr
 	return updateLabelRequest
 }
 
-func createLabelField(plan fieldInterface) (diags diag.Diagnostics) {
+func createLabelField(ctx context.Context, client *gdriveClient, plan fieldInterface) (diags diag.Diagnostics) {
 	updateLabelRequest := newUpdateLabelRequest(plan)
 	field := plan.toField()
 	labelId := plan.getLabelId()
@@ -142,7 +142,7 @@ func createLabelField(plan fieldInterface) (diags diag.Diagnostics) {
 			},
 		},
 	}
-	updatedLabel, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(labelId, "labels/"), "*", updateLabelRequest)
+	updatedLabel, err := client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(labelId, "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		diags.AddError("Client Error", fmt.Sprintf("Unable to create field, got error: %s", err))
 		return diags
@@ -162,7 +162,7 @@ func createLabelField(plan fieldInterface) (diags diag.Diagnostics) {
 	return
 }
 
-func deleteLabelField(state fieldInterface) (diags diag.Diagnostics) {
+func deleteLabelField(ctx context.Context, client *gdriveClient, state fieldInterface) (diags diag.Diagnostics) {
 	updateLabelRequest := newUpdateLabelRequest(state)
 	updateLabelRequest.Requests = []*drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelRequestRequest{
 		{
@@ -171,7 +171,7 @@ func deleteLabelField(state fieldInterface) (diags diag.Diagnostics) {
 			},
 		},
 	}
-	_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(state.getLabelId(), "labels/"), "*", updateLabelRequest)
+	_, err := client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(state.getLabelId(), "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		diags.AddError("Client Error", fmt.Sprintf("Unable to delete text field, got error: %s", err))
 	}
@@ -19,9 +19,7 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -121,10 +119,10 @@ func (LabelModel *gdriveLabelResourceModel) getUseAdminAccess() bool {
 	return LabelModel.UseAdminAccess.ValueBool()
 }
 
-func (labelModel *gdriveLabelResourceModel) populate(ctx context.Context) (diags diag.Diagnostics) {
-	l, err := gsmdrivelabels.GetLabel(gsmhelpers.EnsurePrefix(labelModel.Id.ValueString(), "labels/"), labelModel.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", labelModel.UseAdminAccess.ValueBool())
+func (labelModel *gdriveLabelResourceModel) populate(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
+	l, err := client.getLabel(ctx, gsmhelpers.EnsurePrefix(labelModel.Id.ValueString(), "labels/"), labelModel.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", labelModel.UseAdminAccess.ValueBool())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to get label, got error: %s", err))
+		diags.Append(clientErrorDiagnostic("Unable to get label", err))
 		return
 	}
 	labelModel.LabelId = types.StringValue(l.Id)
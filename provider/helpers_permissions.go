@@ -21,16 +21,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/drive/v3"
 )
 
-func (permissionPolicyModel *gdrivePermissionPolicyResourceModel) populate(ctx context.Context) (diags diag.Diagnostics) {
+func (permissionPolicyModel *gdrivePermissionPolicyResourceModel) populate(ctx context.Context, client *gdriveClient) (diags diag.Diagnostics) {
 	permissionPolicyModel.Permissions = []*gdrivePermissionPolicyPermissionResourceModel{}
-	currentP, err := gsmdrive.ListPermissions(permissionPolicyModel.Id.ValueString(), "", fmt.Sprintf("permissions(%s),nextPageToken", fieldsPermission), permissionPolicyModel.UseDomainAdminAccess.ValueBool(), 1)
-	for i := range currentP {
+	currentP, err := client.listPermissions(ctx, permissionPolicyModel.Id.ValueString(), fmt.Sprintf("permissions(%s),nextPageToken", fieldsPermission), permissionPolicyModel.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		diags.Append(clientErrorDiagnostic("Unable to list permissions on file", err))
+		return diags
+	}
+	for _, i := range currentP {
 		if i.PermissionDetails != nil && i.PermissionDetails[0].Inherited {
 			continue
 		}
@@ -47,10 +50,6 @@ func (permissionPolicyModel *gdrivePermissionPolicyResourceModel) populate(ctx c
 		}
 		permissionPolicyModel.Permissions = append(permissionPolicyModel.Permissions, p)
 	}
-	e := <-err
-	if e != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to list permissions on file, got error: %s", e))
-	}
 	permissionPolicyModel.FileId = permissionPolicyModel.Id
 	return diags
 }
@@ -72,7 +71,7 @@ func (permissionModel *gdrivePermissionPolicyPermissionResourceModel) toRequest(
 	}
 }
 
-func setPermissionDiffs(plan, state *gdrivePermissionPolicyResourceModel) (diags diag.Diagnostics) {
+func setPermissionDiffs(ctx context.Context, client *gdriveClient, plan, state *gdrivePermissionPolicyResourceModel) (diags diag.Diagnostics) {
 	fileId := plan.FileId.ValueString()
 	useDomAccess := plan.UseDomainAdminAccess.ValueBool()
 	planPermissions := plan.toMap()
@@ -82,7 +81,7 @@ func setPermissionDiffs(plan, state *gdrivePermissionPolicyResourceModel) (diags
 		if permissionAlreadyExists {
 			planPermissions[i].PermissionId = statePermissions[i].PermissionId
 			if !planPermissions[i].Role.Equal(statePermissions[i].Role) {
-				_, err := gsmdrive.UpdatePermission(fileId, statePermissions[i].PermissionId.ValueString(), fieldsPermission, useDomAccess, false, &drive.Permission{
+				_, err := client.updatePermission(ctx, fileId, statePermissions[i].PermissionId.ValueString(), fieldsPermission, useDomAccess, false, &drive.Permission{
 					Role: planPermissions[i].Role.ValueString(),
 				})
 				if err != nil {
@@ -91,7 +90,7 @@ func setPermissionDiffs(plan, state *gdrivePermissionPolicyResourceModel) (diags
 				}
 			}
 		} else {
-			p, err := gsmdrive.CreatePermission(fileId, planPermissions[i].EmailMessage.ValueString(), fieldsPermission, useDomAccess, planPermissions[i].SendNotificationEmail.ValueBool(), planPermissions[i].TransferOwnership.ValueBool(), planPermissions[i].MoveToNewOwnersRoot.ValueBool(), planPermissions[i].toRequest())
+			p, err := client.createPermission(ctx, fileId, planPermissions[i].EmailMessage.ValueString(), fieldsPermission, useDomAccess, planPermissions[i].SendNotificationEmail.ValueBool(), planPermissions[i].TransferOwnership.ValueBool(), planPermissions[i].MoveToNewOwnersRoot.ValueBool(), planPermissions[i].toRequest())
 			if err != nil {
 				diags.AddError("Client Error", fmt.Sprintf("Unable to create permission on file, got error: %s", err))
 				return
@@ -102,7 +101,7 @@ func setPermissionDiffs(plan, state *gdrivePermissionPolicyResourceModel) (diags
 	for i := range statePermissions {
 		_, permissionStillPlanned := planPermissions[i]
 		if !permissionStillPlanned {
-			_, err := gsmdrive.DeletePermission(fileId, statePermissions[i].PermissionId.ValueString(), useDomAccess)
+			err := client.deletePermission(ctx, fileId, statePermissions[i].PermissionId.ValueString(), useDomAccess)
 			if err != nil {
 				diags.AddError("Client Error", fmt.Sprintf("Unable to delete permission from file, got error: %s", err))
 				return
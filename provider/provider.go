@@ -20,22 +20,18 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"time"
 
-	"encoding/json"
-
-	"github.com/hanneshayashi/gsm/gsmauth"
-	"github.com/hanneshayashi/gsm/gsmcibeta"
-	"github.com/hanneshayashi/gsm/gsmdrive"
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
-	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -47,11 +43,35 @@ type gdriveProvider struct {
 
 // gdriveProviderModel describes the provider data model.
 type gdriveProviderModel struct {
-	ServiceAccountKey types.String `tfsdk:"service_account_key"`
-	ServiceAccount    types.String `tfsdk:"service_account"`
-	Subject           types.String `tfsdk:"subject"`
-	RetryOn           types.List   `tfsdk:"retry_on"`
-	Scopes            types.List   `tfsdk:"scopes"`
+	ServiceAccountKey     types.String              `tfsdk:"service_account_key"`
+	ExternalAccount       types.String              `tfsdk:"external_account_credentials"`
+	AccessToken           types.String              `tfsdk:"access_token"`
+	ServiceAccount        types.String              `tfsdk:"service_account"`
+	Subject               types.String              `tfsdk:"subject"`
+	DriveEndpoint         types.String              `tfsdk:"drive_endpoint"`
+	DriveLabelsEndpoint   types.String              `tfsdk:"drivelabels_endpoint"`
+	CloudIdentityEndpoint types.String              `tfsdk:"cloudidentity_endpoint"`
+	RetryOn               types.List                `tfsdk:"retry_on"`
+	Scopes                types.List                `tfsdk:"scopes"`
+	MaxQPSRead            types.Float64             `tfsdk:"max_qps_read"`
+	MaxQPSWrite           types.Float64             `tfsdk:"max_qps_write"`
+	ReadOnly              types.Bool                `tfsdk:"read_only"`
+	Retry                 *gdriveProviderRetryModel `tfsdk:"retry"`
+}
+
+type gdriveProviderRetryModel struct {
+	InitialInterval types.String                        `tfsdk:"initial_interval"`
+	MaxInterval     types.String                        `tfsdk:"max_interval"`
+	MaxElapsedTime  types.String                        `tfsdk:"max_elapsed_time"`
+	Multiplier      types.Float64                       `tfsdk:"multiplier"`
+	Jitter          types.Float64                       `tfsdk:"jitter"`
+	Override        []*gdriveProviderRetryOverrideModel `tfsdk:"override"`
+}
+
+type gdriveProviderRetryOverrideModel struct {
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	Retry          types.Bool   `tfsdk:"retry"`
+	MaxElapsedTime types.String `tfsdk:"max_elapsed_time"`
 }
 
 func (p *gdriveProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -69,6 +89,33 @@ func (p *gdriveProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: `The path to or the content of a key file for your Service Account.
 Leave empty if you want to use Application Default Credentials (ADC) (**recommended**).<br>
 You can also use the "SERVICE_ACCOUNT_KEY" environment variable to store either the path to the key file or the key itself (in JSON format).`,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("external_account_credentials"),
+						path.MatchRoot("access_token"),
+					}...),
+				},
+			},
+			"external_account_credentials": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: `The path to or the content of an external account (Workload Identity Federation) credential configuration, e.g., for GitHub Actions OIDC tokens or AWS.
+The provider uses these credentials to sign a JWT for the Service Account set in "service_account" via the IAM Credentials API to impersonate the "subject" with Domain Wide Delegation (DWD).
+The federated identity needs the *Service Account Token Creator* role for that Service Account.<br>
+You can also use the "EXTERNAL_ACCOUNT_CREDENTIALS" environment variable.`,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("access_token"),
+					}...),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: `A short-lived OAuth 2.0 access token that was created outside of Terraform.
+If "service_account" is set, the token is used to sign a JWT for that Service Account via the IAM Credentials API to impersonate the "subject" with Domain Wide Delegation (DWD).
+Otherwise, the token is used to call the APIs directly and "subject" is ignored.<br>
+You can also use the "ACCESS_TOKEN" environment variable.`,
 			},
 			"service_account": schema.StringAttribute{
 				Optional: true,
@@ -84,8 +131,11 @@ You can also use the "SUBJECT" environment variable.`,
 			"retry_on": schema.ListAttribute{
 				Optional: true,
 				MarkdownDescription: `A list of HTTP error codes you want the provider to retry on.
-If this is unset, the provider will retry on 404 and 502 using an exponential backoff strategy. If you DON'T want the provider to retry on any error, set this to an empty list.
-The provider will ALWAYS retry on 403 errors that indicate a rate limiting / quota issue.`,
+If this is unset, the provider will retry on 502 using an exponential backoff strategy. If you DON'T want the provider to retry on any error, set this to an empty list.
+The provider will ALWAYS retry on 403 and 429 errors that indicate a rate limiting / quota issue.
+The provider NEVER retries on 404 errors when refreshing a resource. Resources that were deleted outside of Terraform are removed from the state instead.
+After creating a Shared Drive, the provider waits until the Drive API finds it, so that dependent resources do not need to retry on 404.<br>
+Use the "retry" block to tune the backoff strategy or to override the behaviour for individual status codes.`,
 				ElementType: types.Int64Type,
 			},
 			"scopes": schema.ListAttribute{
@@ -98,6 +148,119 @@ If this is unset, the provider will use the following scopes that must be added
 * https://www.googleapis.com/auth/cloud-identity.orgunits`,
 				ElementType: types.StringType,
 			},
+			"drive_endpoint": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: `The base URL of the Drive API, including the API version (e.g., "https://drive.example.com/drive/v3/").
+Leave empty to use the default Google endpoint.<br>
+You can also use the "DRIVE_ENDPOINT" environment variable.`,
+			},
+			"drivelabels_endpoint": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: `The base URL of the Drive Labels API (e.g., "https://drivelabels.example.com/").
+Leave empty to use the default Google endpoint.<br>
+You can also use the "DRIVELABELS_ENDPOINT" environment variable.`,
+			},
+			"cloudidentity_endpoint": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: `The base URL of the Cloud Identity API (e.g., "https://cloudidentity.example.com/").
+Leave empty to use the default Google endpoint.<br>
+You can also use the "CLOUDIDENTITY_ENDPOINT" environment variable.`,
+			},
+			"max_qps_read": schema.Float64Attribute{
+				Optional: true,
+				MarkdownDescription: `The maximum number of read (GET) requests per second the provider sends to the Google APIs.
+The budget is shared by all resources and data sources of this provider instance, regardless of the user they impersonate. Leave empty for no limit.`,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_qps_write": schema.Float64Attribute{
+				Optional: true,
+				MarkdownDescription: `The maximum number of write (POST, PATCH, PUT, DELETE) requests per second the provider sends to the Google APIs.
+The budget is shared by all resources and data sources of this provider instance, regardless of the user they impersonate. Leave empty for no limit.<br>
+Use this to stay below the per-user write quota of the Drive API when using a high parallelism.`,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: `If true, the provider refuses every API call that could modify data (e.g., creating, updating or deleting files, permissions, Shared Drives or Labels).
+Reads and data sources still work. Use this to run drift detection plans with privileged credentials.`,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: `Configures the exponential backoff strategy the provider uses when an API call fails with a retryable error.
+All durations use Go's duration format (e.g., "500ms", "30s", "10m") and must be greater than zero. max_interval must not be less than initial_interval.`,
+				Attributes: map[string]schema.Attribute{
+					"initial_interval": schema.StringAttribute{
+						MarkdownDescription: "The time to wait before the first retry. Defaults to \"500ms\".",
+						Optional:            true,
+						Validators: []validator.String{
+							positiveDuration(),
+						},
+					},
+					"max_interval": schema.StringAttribute{
+						MarkdownDescription: "The upper limit for the time to wait between two retries. Defaults to \"60s\".",
+						Optional:            true,
+						Validators: []validator.String{
+							positiveDuration(),
+						},
+					},
+					"max_elapsed_time": schema.StringAttribute{
+						MarkdownDescription: "The total time after which the provider gives up retrying a call. Defaults to \"3m\".",
+						Optional:            true,
+						Validators: []validator.String{
+							positiveDuration(),
+						},
+					},
+					"multiplier": schema.Float64Attribute{
+						MarkdownDescription: "The factor by which the wait time grows after every retry. Defaults to 1.5.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.AtLeast(1),
+						},
+					},
+					"jitter": schema.Float64Attribute{
+						MarkdownDescription: `The randomization factor that is applied to every wait time (between 0 and 1). Defaults to 0.5.
+A value of 0.5 means that the provider waits between 50% and 150% of the calculated interval.`,
+						Optional: true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 1),
+						},
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"override": schema.ListNestedBlock{
+						MarkdownDescription: `Overrides the retry behaviour for a single HTTP status code.
+Overrides take precedence over "retry_on".`,
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"status_code": schema.Int64Attribute{
+									MarkdownDescription: "The HTTP status code this override applies to.",
+									Required:            true,
+									Validators: []validator.Int64{
+										int64validator.Between(400, 599),
+									},
+								},
+								"retry": schema.BoolAttribute{
+									MarkdownDescription: `Whether the provider should retry on this status code. Defaults to true.
+Note that errors that indicate a rate limiting / quota issue are always retried.`,
+									Optional: true,
+								},
+								"max_elapsed_time": schema.StringAttribute{
+									MarkdownDescription: "The total time after which the provider gives up retrying a call that failed with this status code. Defaults to the max_elapsed_time of the retry block.",
+									Optional:            true,
+									Validators: []validator.String{
+										positiveDuration(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -112,6 +275,14 @@ func (p *gdriveProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	if serviceAccountKey == "" {
 		serviceAccountKey = os.Getenv("SERVICE_ACCOUNT_KEY")
 	}
+	externalAccount := data.ExternalAccount.ValueString()
+	if externalAccount == "" {
+		externalAccount = os.Getenv("EXTERNAL_ACCOUNT_CREDENTIALS")
+	}
+	accessToken := data.AccessToken.ValueString()
+	if accessToken == "" {
+		accessToken = os.Getenv("ACCESS_TOKEN")
+	}
 	serviceAccount := data.ServiceAccount.ValueString()
 	if serviceAccount == "" {
 		serviceAccount = os.Getenv("SERVICE_ACCOUNT")
@@ -120,7 +291,7 @@ func (p *gdriveProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	if subject == "" {
 		subject = os.Getenv("SUBJECT")
 	}
-	if subject == "" {
+	if subject == "" && (accessToken == "" || serviceAccount != "") {
 		resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Subject must be set"))
 		return
 	}
@@ -138,60 +309,137 @@ func (p *gdriveProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			return
 		}
 	}
-	var client *http.Client
+	authConfig := &gdriveAuthConfig{
+		serviceAccount: serviceAccount,
+		accessToken:    accessToken,
+		scopes:         scopes,
+	}
 	var err error
-	if serviceAccountKey != "" {
-		var saKey []byte
-		s := []byte(serviceAccountKey)
-		if json.Valid(s) {
-			saKey = s
-		} else {
-			var f *os.File
-			f, err = os.Open(serviceAccountKey)
-			if err != nil {
-				resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to open Service Account Key file, got error: %s", err))
-				return
-			}
-			saKey, err = io.ReadAll(f)
-			if err != nil {
-				resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to read Service Account Key file, got error: %s", err))
-				return
-			}
-		}
-		client, err = gsmauth.GetClient(subject, saKey, scopes...)
+	if accessToken == "" && externalAccount != "" {
+		authConfig.externalAccountCredential, err = readCredentials(externalAccount)
 		if err != nil {
-			resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to get client, got error: %s", err))
+			resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to read external account credentials, got error: %s", err))
 			return
 		}
-	} else {
-		client, err = gsmauth.GetClientADC(subject, serviceAccount, scopes...)
+	} else if accessToken == "" && serviceAccountKey != "" {
+		authConfig.serviceAccountKey, err = readCredentials(serviceAccountKey)
 		if err != nil {
-			resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to get ADC client, got error: %s", err))
+			resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to read Service Account Key file, got error: %s", err))
 			return
 		}
 	}
-	gsmdrive.SetClient(client)
-	gsmcibeta.SetClient(client)
-	gsmdrivelabels.SetClient(client)
 	var retryOn []int
 	if data.RetryOn.IsNull() {
-		retryOn = []int{404, 502}
+		retryOn = []int{502}
 	} else {
 		resp.Diagnostics.Append(data.RetryOn.ElementsAs(ctx, &retryOn, false)...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
+	retryPolicy, err := data.Retry.toRetryPolicy(retryOn)
+	if err != nil {
+		resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to parse retry configuration, got error: %s", err))
+		return
+	}
+	clientConfig := &gdriveClientConfig{
+		driveEndpoint:         data.DriveEndpoint.ValueString(),
+		driveLabelsEndpoint:   data.DriveLabelsEndpoint.ValueString(),
+		cloudIdentityEndpoint: data.CloudIdentityEndpoint.ValueString(),
+		retryPolicy:           retryPolicy,
+		readLimit:             newTokenBucket(data.MaxQPSRead.ValueFloat64()),
+		writeLimit:            newTokenBucket(data.MaxQPSWrite.ValueFloat64()),
+		readOnly:              data.ReadOnly.ValueBool(),
+	}
+	if clientConfig.driveEndpoint == "" {
+		clientConfig.driveEndpoint = os.Getenv("DRIVE_ENDPOINT")
+	}
+	if clientConfig.driveLabelsEndpoint == "" {
+		clientConfig.driveLabelsEndpoint = os.Getenv("DRIVELABELS_ENDPOINT")
+	}
+	if clientConfig.cloudIdentityEndpoint == "" {
+		clientConfig.cloudIdentityEndpoint = os.Getenv("CLOUDIDENTITY_ENDPOINT")
+	}
+	providerClient, err := newClient(ctx, authConfig, subject, clientConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to create API client, got error: %s", err))
+		return
+	}
+	resp.ResourceData = providerClient
+	resp.DataSourceData = providerClient
+}
+
+// parseDuration parses d or returns def, if d is not set.
+// Durations that are not greater than zero are rejected.
+func parseDuration(d types.String, def time.Duration) (time.Duration, error) {
+	if d.IsNull() || d.IsUnknown() {
+		return def, nil
+	}
+	duration, err := time.ParseDuration(d.ValueString())
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be greater than zero, got: %s", d.ValueString())
+	}
+	return duration, nil
+}
+
+// toRetryPolicy combines the retry block with the status codes from retry_on.
+// retryModel may be nil, in which case the default backoff strategy is used.
+func (retryModel *gdriveProviderRetryModel) toRetryPolicy(retryOn []int) (*retryPolicy, error) {
+	policy := newRetryPolicy()
+	if retryModel != nil {
+		var err error
+		policy.initialInterval, err = parseDuration(retryModel.InitialInterval, policy.initialInterval)
+		if err != nil {
+			return nil, fmt.Errorf("initial_interval: %w", err)
+		}
+		policy.maxInterval, err = parseDuration(retryModel.MaxInterval, policy.maxInterval)
+		if err != nil {
+			return nil, fmt.Errorf("max_interval: %w", err)
+		}
+		policy.maxElapsedTime, err = parseDuration(retryModel.MaxElapsedTime, policy.maxElapsedTime)
+		if err != nil {
+			return nil, fmt.Errorf("max_elapsed_time: %w", err)
+		}
+		if policy.maxInterval < policy.initialInterval {
+			return nil, fmt.Errorf("max_interval (%s) must not be less than initial_interval (%s)", policy.maxInterval, policy.initialInterval)
+		}
+		if !retryModel.Multiplier.IsNull() {
+			policy.multiplier = retryModel.Multiplier.ValueFloat64()
+		}
+		if !retryModel.Jitter.IsNull() {
+			policy.jitter = retryModel.Jitter.ValueFloat64()
+		}
+	}
 	for i := range retryOn {
-		gsmhelpers.RetryOn = append(gsmhelpers.RetryOn, retryOn[i])
+		policy.statusCodes[retryOn[i]] = policy.maxElapsedTime
+	}
+	if retryModel != nil {
+		for _, override := range retryModel.Override {
+			statusCode := int(override.StatusCode.ValueInt64())
+			if !override.Retry.IsNull() && !override.Retry.ValueBool() {
+				delete(policy.statusCodes, statusCode)
+				continue
+			}
+			maxElapsedTime, err := parseDuration(override.MaxElapsedTime, policy.maxElapsedTime)
+			if err != nil {
+				return nil, fmt.Errorf("max_elapsed_time of override for status code %d: %w", statusCode, err)
+			}
+			policy.statusCodes[statusCode] = maxElapsedTime
+		}
 	}
-	gsmhelpers.SetStandardRetrier(time.Duration(500*time.Millisecond), time.Duration(60*time.Second), time.Duration(3*time.Minute))
+	return policy, nil
 }
 
 func (p *gdriveProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		newDrive,
 		newFile,
+		newShortcut,
+		newFileCopy,
+		newFolderSync,
 		newPermission,
 		newPermissionPolicy,
 		newLabelAssignment,
@@ -213,6 +461,7 @@ func (p *gdriveProvider) DataSources(ctx context.Context) []func() datasource.Da
 		newDriveDataSource,
 		newDrivesDataSource,
 		newFileDataSource,
+		newFileByPathDataSource,
 		newFilesDataSource,
 		newLabelDataSource,
 		newLabelsDataSource,
@@ -18,9 +18,13 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package provider
 
 import (
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -37,3 +41,118 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    types.String
+		duration time.Duration
+		err      bool
+	}{
+		{"null", types.StringNull(), time.Minute, false},
+		{"unknown", types.StringUnknown(), time.Minute, false},
+		{"valid", types.StringValue("1m30s"), 90 * time.Second, false},
+		{"invalid", types.StringValue("ten seconds"), 0, true},
+		{"missing unit", types.StringValue("10"), 0, true},
+		{"zero", types.StringValue("0s"), 0, true},
+		{"negative", types.StringValue("-5m"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, err := parseDuration(tt.value, time.Minute)
+			if (err != nil) != tt.err {
+				t.Fatalf("parseDuration() error = %v, want error: %t", err, tt.err)
+			}
+			if duration != tt.duration {
+				t.Errorf("parseDuration() = %s, want %s", duration, tt.duration)
+			}
+		})
+	}
+}
+
+func TestToRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		model       *gdriveProviderRetryModel
+		retryOn     []int
+		statusCodes map[int]time.Duration
+		err         string
+	}{
+		{
+			name:        "defaults",
+			retryOn:     []int{http.StatusServiceUnavailable},
+			statusCodes: map[int]time.Duration{http.StatusServiceUnavailable: retryDefaultMaxElapsedTime},
+		},
+		{
+			name: "overrides",
+			model: &gdriveProviderRetryModel{
+				MaxElapsedTime: types.StringValue("10m"),
+				Override: []*gdriveProviderRetryOverrideModel{
+					{StatusCode: types.Int64Value(http.StatusServiceUnavailable), MaxElapsedTime: types.StringValue("1h")},
+					{StatusCode: types.Int64Value(http.StatusBadGateway), Retry: types.BoolValue(false)},
+					{StatusCode: types.Int64Value(http.StatusConflict), Retry: types.BoolValue(true)},
+				},
+			},
+			retryOn: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusInternalServerError},
+			statusCodes: map[int]time.Duration{
+				http.StatusServiceUnavailable:  time.Hour,
+				http.StatusInternalServerError: 10 * time.Minute,
+				http.StatusConflict:            10 * time.Minute,
+			},
+		},
+		{
+			name:  "invalid initial_interval",
+			model: &gdriveProviderRetryModel{InitialInterval: types.StringValue("1 second")},
+			err:   "initial_interval",
+		},
+		{
+			name:  "zero max_interval",
+			model: &gdriveProviderRetryModel{MaxInterval: types.StringValue("0s")},
+			err:   "max_interval",
+		},
+		{
+			name:  "negative max_elapsed_time",
+			model: &gdriveProviderRetryModel{MaxElapsedTime: types.StringValue("-1m")},
+			err:   "max_elapsed_time",
+		},
+		{
+			name: "max_interval less than initial_interval",
+			model: &gdriveProviderRetryModel{
+				InitialInterval: types.StringValue("10s"),
+				MaxInterval:     types.StringValue("5s"),
+			},
+			err: "must not be less than initial_interval",
+		},
+		{
+			name: "invalid max_elapsed_time of override",
+			model: &gdriveProviderRetryModel{
+				Override: []*gdriveProviderRetryOverrideModel{
+					{StatusCode: types.Int64Value(http.StatusServiceUnavailable), MaxElapsedTime: types.StringValue("forever")},
+				},
+			},
+			err: "override for status code 503",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := tt.model.toRetryPolicy(tt.retryOn)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("toRetryPolicy() error = %v, want error containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toRetryPolicy() error = %v", err)
+			}
+			if len(policy.statusCodes) != len(tt.statusCodes) {
+				t.Errorf("toRetryPolicy() status codes = %v, want %v", policy.statusCodes, tt.statusCodes)
+			}
+			for code, maxElapsedTime := range tt.statusCodes {
+				if policy.statusCodes[code] != maxElapsedTime {
+					t.Errorf("toRetryPolicy() status codes = %v, want %v", policy.statusCodes, tt.statusCodes)
+				}
+			}
+		})
+	}
+}
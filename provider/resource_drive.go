@@ -22,13 +22,18 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/drive/v3"
 )
@@ -36,9 +41,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &gdriveDriveResource{}
 var _ resource.ResourceWithImportState = &gdriveDriveResource{}
+var _ resource.ResourceWithModifyPlan = &gdriveDriveResource{}
 
 const (
-	fieldsDrive         = "id,name,restrictions"
+	fieldsDrive         = "id,name,restrictions,colorRgb,hidden"
 	adminAttributeDrive = "use_domain_admin_access"
 )
 
@@ -48,32 +54,59 @@ func newDrive() resource.Resource {
 
 // gdriveDriveResource defines the resource implementation.
 type gdriveDriveResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type driveRestrictionsModel struct {
+	AdminManagedRestrictions                  types.Bool `tfsdk:"admin_managed_restrictions"`
+	CopyRequiresWriterPermission              types.Bool `tfsdk:"copy_requires_writer_permission"`
+	DomainUsersOnly                           types.Bool `tfsdk:"domain_users_only"`
+	DriveMembersOnly                          types.Bool `tfsdk:"drive_members_only"`
+	SharingFoldersRequiresOrganizerPermission types.Bool `tfsdk:"sharing_folders_requires_organizer_permission"`
+	DownloadRestrictedForReaders              types.Bool `tfsdk:"download_restricted_for_readers"`
+	DownloadRestrictedForWriters              types.Bool `tfsdk:"download_restricted_for_writers"`
+}
+
+// driveRestrictionsModelV0 describes the restrictions in the resource data model V0.
+type driveRestrictionsModelV0 struct {
 	AdminManagedRestrictions     types.Bool `tfsdk:"admin_managed_restrictions"`
 	CopyRequiresWriterPermission types.Bool `tfsdk:"copy_requires_writer_permission"`
 	DomainUsersOnly              types.Bool `tfsdk:"domain_users_only"`
 	DriveMembersOnly             types.Bool `tfsdk:"drive_members_only"`
 }
 
+type driveBackgroundImageFileModel struct {
+	Id          types.String  `tfsdk:"id"`
+	XCoordinate types.Float64 `tfsdk:"x_coordinate"`
+	YCoordinate types.Float64 `tfsdk:"y_coordinate"`
+	Width       types.Float64 `tfsdk:"width"`
+}
+
 // gdriveDriveResourceModelV1 describes the resource data model V1.
 type gdriveDriveResourceModelV1 struct {
-	Restrictions         *driveRestrictionsModel `tfsdk:"restrictions"`
-	Name                 types.String            `tfsdk:"name"`
-	DriveId              types.String            `tfsdk:"drive_id"`
-	Id                   types.String            `tfsdk:"id"`
-	UseDomainAdminAccess types.Bool              `tfsdk:"use_domain_admin_access"`
+	Restrictions         *driveRestrictionsModel        `tfsdk:"restrictions"`
+	BackgroundImageFile  *driveBackgroundImageFileModel `tfsdk:"background_image_file"`
+	Name                 types.String                   `tfsdk:"name"`
+	ColorRgb             types.String                   `tfsdk:"color_rgb"`
+	ThemeId              types.String                   `tfsdk:"theme_id"`
+	Hidden               types.Bool                     `tfsdk:"hidden"`
+	DriveId              types.String                   `tfsdk:"drive_id"`
+	Id                   types.String                   `tfsdk:"id"`
+	UseDomainAdminAccess types.Bool                     `tfsdk:"use_domain_admin_access"`
+	DeleteBehavior       types.String                   `tfsdk:"delete_behavior"`
+	DeletionProtection   types.Bool                     `tfsdk:"deletion_protection"`
+	ForceDestroy         types.Bool                     `tfsdk:"force_destroy"`
+	ImpersonateSubject   types.String                   `tfsdk:"impersonate_subject"`
+	Timeouts             timeouts.Value                 `tfsdk:"timeouts"`
 }
 
 // gdriveDriveResourceModelV0 describes the resource data model V0.
 type gdriveDriveResourceModelV0 struct {
-	Name                 types.String              `tfsdk:"name"`
-	Id                   types.String              `tfsdk:"id"`
-	Restrictions         []*driveRestrictionsModel `tfsdk:"restrictions"`
-	WaitAfterCreate      types.Int64               `tfsdk:"wait_after_create"`
-	UseDomainAdminAccess types.Bool                `tfsdk:"use_domain_admin_access"`
+	Name                 types.String                `tfsdk:"name"`
+	Id                   types.String                `tfsdk:"id"`
+	Restrictions         []*driveRestrictionsModelV0 `tfsdk:"restrictions"`
+	WaitAfterCreate      types.Int64                 `tfsdk:"wait_after_create"`
+	UseDomainAdminAccess types.Bool                  `tfsdk:"use_domain_admin_access"`
 }
 
 func (r *gdriveDriveResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -82,10 +115,31 @@ func (r *gdriveDriveResource) Metadata(ctx context.Context, req resource.Metadat
 
 func (r *gdriveDriveResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Version:             1,
-		MarkdownDescription: "Creates a Shared Drive.",
+		Version: 1,
+		MarkdownDescription: `Creates a Shared Drive.
+
+If a create request fails after the Shared Drive was created (e.g., because the response was lost), the provider looks up the Shared Drive by its name and creation time.
+If it cannot be identified unambiguously, the apply fails and the Shared Drive has to be imported.
+
+The request ID that prevents duplicates is only stable within a single apply. If an apply is interrupted (e.g., the provider crashes) after the Shared Drive was created, but before it was saved to the state, the next apply creates a second Shared Drive.
+Import the first Shared Drive or delete it in that case.`,
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
+			"delete_behavior": rsDeleteBehavior(`What happens to the Shared Drive when the resource is destroyed:
+* delete: The Shared Drive is deleted. This is the default.
+* abandon: The Shared Drive is only removed from the Terraform state.
+
+Shared Drives cannot be moved to the trash with the API.`, deleteBehaviorDelete, deleteBehaviorDelete, deleteBehaviorAbandon),
+			"deletion_protection": rsDeletionProtection(),
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: `If set to true, the Shared Drive is deleted even if it still contains files and folders.
+
+With use_domain_admin_access, the items are deleted together with the Shared Drive. Otherwise, the provider deletes all items in the Shared Drive first, which requires the organizer role.`,
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"drive_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the Shared Drive.",
@@ -101,8 +155,66 @@ func (r *gdriveDriveResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Use domain admin access.",
 				Optional:            true,
 			},
+			"color_rgb": schema.StringAttribute{
+				MarkdownDescription: `The color of the Shared Drive as an RGB hex string, e.g. "#4986e7".
+
+If neither color_rgb nor theme_id are set, the color of the theme that Google Drive chose for the Shared Drive is used.`,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("theme_id")),
+				},
+			},
+			"theme_id": schema.StringAttribute{
+				MarkdownDescription: `The ID of the theme from which the background image and color of the Shared Drive are set.
+
+The available themes are returned by the about.get method of the Drive API (driveThemes).
+The theme is not returned by the API, so changes made outside of Terraform are not detected.`,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("color_rgb"), path.MatchRoot("background_image_file")),
+				},
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Shared Drive is hidden from the default view of the impersonated user.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"background_image_file": schema.SingleNestedBlock{
+				MarkdownDescription: `An image file and cropping parameters from which the background image of the Shared Drive is set.
+
+The image file is not returned by the API, so changes made outside of Terraform are not detected.`,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "The ID of an image file in Google Drive to use for the background image.",
+						Optional:            true,
+					},
+					"x_coordinate": schema.Float64Attribute{
+						MarkdownDescription: "The X coordinate of the upper left corner of the cropping area in the background image. This is a value in the closed range of 0 to 1.",
+						Optional:            true,
+					},
+					"y_coordinate": schema.Float64Attribute{
+						MarkdownDescription: "The Y coordinate of the upper left corner of the cropping area in the background image. This is a value in the closed range of 0 to 1.",
+						Optional:            true,
+					},
+					"width": schema.Float64Attribute{
+						MarkdownDescription: "The width of the cropped image in the closed range of 0 to 1.",
+						Optional:            true,
+					},
+				},
+			},
 			"restrictions": schema.SingleNestedBlock{
 				MarkdownDescription: "The restrictions that should be set on the Shared Drive.",
 				Attributes: map[string]schema.Attribute{
@@ -134,6 +246,26 @@ This restriction may be overridden by other sharing policies controlled outside
 						Computed:            true,
 						Default:             booldefault.StaticBool(false),
 					},
+					"sharing_folders_requires_organizer_permission": schema.BoolAttribute{
+						MarkdownDescription: "If true, only users with the organizer role can share folders. If false, users with either the organizer role or the file organizer role can share folders.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"download_restricted_for_readers": schema.BoolAttribute{
+						MarkdownDescription: "Whether downloading and copying files inside this Shared Drive is restricted for readers and commenters.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"download_restricted_for_writers": schema.BoolAttribute{
+						MarkdownDescription: `Whether downloading and copying files inside this Shared Drive is restricted for writers.
+
+If this restriction is set to true, downloading is restricted for readers as well. Set download_restricted_for_readers to true in this case to avoid a permanent diff.`,
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
 				},
 			},
 		},
@@ -194,7 +326,27 @@ func (r *gdriveDriveResource) UpgradeState(ctx context.Context) map[int64]resour
 					UseDomainAdminAccess: stateV0.UseDomainAdminAccess,
 					Id:                   stateV0.Id,
 					DriveId:              stateV0.Id,
-					Restrictions:         stateV0.Restrictions[0],
+					Restrictions: &driveRestrictionsModel{
+						AdminManagedRestrictions:                  stateV0.Restrictions[0].AdminManagedRestrictions,
+						CopyRequiresWriterPermission:              stateV0.Restrictions[0].CopyRequiresWriterPermission,
+						DomainUsersOnly:                           stateV0.Restrictions[0].DomainUsersOnly,
+						DriveMembersOnly:                          stateV0.Restrictions[0].DriveMembersOnly,
+						SharingFoldersRequiresOrganizerPermission: types.BoolValue(false),
+						DownloadRestrictedForReaders:              types.BoolValue(false),
+						DownloadRestrictedForWriters:              types.BoolValue(false),
+					},
+					DeleteBehavior:     types.StringValue(deleteBehaviorDelete),
+					DeletionProtection: types.BoolValue(false),
+					ForceDestroy:       types.BoolValue(false),
+					Hidden:             types.BoolValue(false),
+					Timeouts: timeouts.Value{
+						Object: types.ObjectNull(map[string]attr.Type{
+							"create": types.StringType,
+							"read":   types.StringType,
+							"update": types.StringType,
+							"delete": types.StringType,
+						}),
+					},
 				}
 				resp.Diagnostics.Append(resp.State.Set(ctx, stateV1)...)
 			},
@@ -208,12 +360,12 @@ func (r *gdriveDriveResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -228,29 +380,74 @@ func (r *gdriveDriveResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	driveReq := &drive.Drive{
-		Name: plan.Name.ValueString(),
+		Name:    plan.Name.ValueString(),
+		ThemeId: plan.ThemeId.ValueString(),
 	}
-	d, err := gsmdrive.CreateDrive(driveReq, fieldsDrive, false)
+	// The request ID cannot be generated in ModifyPlan and stored in the private state, because the framework
+	// does not pass the planned private state to Create. A failed Create does not save any state either,
+	// so the request ID cannot survive an interrupted apply.
+	requestID, err := uuid.GenerateUUID()
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create drive, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to generate request ID, got error: %s", err))
+		return
+	}
+	d, diags := plan.createDrive(ctx, client, requestID, driveReq)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 	plan.Id = types.StringValue(d.Id)
 	plan.DriveId = types.StringValue(d.Id)
+	colorRgb := plan.ColorRgb
+	plan.ColorRgb = types.StringValue(d.ColorRgb)
+	hidden := plan.Hidden
+	plan.Hidden = types.BoolValue(d.Hidden)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	err = waitForDrive(ctx, client, d.Id, plan.UseDomainAdminAccess.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find drive %s after creating it, got error: %s", d.Id, err))
+		return
+	}
+	driveReq = &drive.Drive{}
 	if plan.Restrictions != nil {
-		driveReq = &drive.Drive{
-			Restrictions: plan.Restrictions.toDriveRestrictions(),
+		driveReq.Restrictions = plan.Restrictions.toDriveRestrictions()
+	}
+	if !colorRgb.IsUnknown() {
+		driveReq.ColorRgb = colorRgb.ValueString()
+	}
+	if plan.BackgroundImageFile != nil {
+		driveReq.BackgroundImageFile = plan.BackgroundImageFile.toBackgroundImageFile()
+	}
+	if driveReq.Restrictions != nil || driveReq.ColorRgb != "" || driveReq.BackgroundImageFile != nil {
+		d, err = client.updateDrive(ctx, d.Id, fieldsDrive, plan.UseDomainAdminAccess.ValueBool(), driveReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update drive, got error: %s", err))
+			return
 		}
-		_, err = gsmdrive.UpdateDrive(d.Id, fieldsDrive, plan.UseDomainAdminAccess.ValueBool(), driveReq)
+		plan.ColorRgb = types.StringValue(d.ColorRgb)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if hidden.ValueBool() {
+		_, err = client.hideDrive(ctx, d.Id, fieldsDrive)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set drive restrictions, got error: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to hide drive, got error: %s", err))
 			return
 		}
+		plan.Hidden = hidden
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	}
 }
 
@@ -260,11 +457,27 @@ func (r *gdriveDriveResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
 	state.DriveId = state.Id
-	resp.Diagnostics.Append(state.populate()...)
+	diags = state.populate(ctx, client)
+	if hasNotFound(diags) {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setDeleteDefaults(&state.DeleteBehavior, &state.DeletionProtection)
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -274,6 +487,12 @@ func (r *gdriveDriveResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveDriveResourceModelV1{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -286,21 +505,85 @@ func (r *gdriveDriveResource) Update(ctx context.Context, req resource.UpdateReq
 	if plan.Restrictions != nil {
 		driveReq.Restrictions = plan.Restrictions.toDriveRestrictions()
 	}
-	_, err := gsmdrive.UpdateDrive(plan.Id.ValueString(), fieldsDrive, plan.UseDomainAdminAccess.ValueBool(), driveReq)
+	if !plan.ThemeId.IsNull() && !plan.ThemeId.Equal(state.ThemeId) {
+		driveReq.ThemeId = plan.ThemeId.ValueString()
+	} else if !plan.ColorRgb.IsUnknown() && !plan.ColorRgb.Equal(state.ColorRgb) {
+		driveReq.ColorRgb = plan.ColorRgb.ValueString()
+	}
+	if plan.BackgroundImageFile.changed(state.BackgroundImageFile) {
+		driveReq.BackgroundImageFile = plan.BackgroundImageFile.toBackgroundImageFile()
+	}
+	d, err := client.updateDrive(ctx, plan.Id.ValueString(), fieldsDrive, plan.UseDomainAdminAccess.ValueBool(), driveReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update drive, got error: %s", err))
 		return
 	}
+	plan.ColorRgb = types.StringValue(d.ColorRgb)
+	if !plan.Hidden.Equal(state.Hidden) {
+		if plan.Hidden.ValueBool() {
+			_, err = client.hideDrive(ctx, plan.Id.ValueString(), fieldsDrive)
+		} else {
+			_, err = client.unhideDrive(ctx, plan.Id.ValueString(), fieldsDrive)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change the visibility of drive, got error: %s", err))
+			return
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func (r *gdriveDriveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	plan := &gdriveDriveResourceModelV1{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := &gdriveDriveResourceModelV1{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A new theme or background image also changes the color of the Shared Drive, unless it is configured.
+	themeChanged := !plan.ThemeId.IsNull() && !plan.ThemeId.Equal(state.ThemeId)
+	imageChanged := plan.BackgroundImageFile.changed(state.BackgroundImageFile)
+	if themeChanged || imageChanged {
+		var colorRgb types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("color_rgb"), &colorRgb)...)
+		if colorRgb.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("color_rgb"), types.StringUnknown())...)
+		}
+	}
+}
+
 func (r *gdriveDriveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	state := &gdriveDriveResourceModelV1{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := gsmdrive.DeleteDrive(state.Id.ValueString(), state.UseDomainAdminAccess.ValueBool())
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, state.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.DeleteBehavior.ValueString() == deleteBehaviorAbandon {
+		abandon(ctx, state.Id)
+		return
+	}
+	if state.ForceDestroy.ValueBool() && !state.UseDomainAdminAccess.ValueBool() {
+		err := emptyDrive(ctx, client, state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete the items in drive, got error: %s", err))
+			return
+		}
+	}
+	err := client.deleteDrive(ctx, state.Id.ValueString(), state.UseDomainAdminAccess.ValueBool(), state.ForceDestroy.ValueBool() && state.UseDomainAdminAccess.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete drive, got error: %s", err))
 		return
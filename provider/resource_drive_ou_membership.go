@@ -23,7 +23,7 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/hanneshayashi/gsm/gsmcibeta"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -44,15 +44,16 @@ func newOrgUnitMembership() resource.Resource {
 
 // gdriveOrgUnitMembershipResource defines the resource implementation.
 type gdriveOrgUnitMembershipResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdriveOrgUnitMembershipResourceModel describes the resource data model.
 type gdriveOrgUnitMembershipResourceModel struct {
-	Parent    types.String `tfsdk:"parent"`
-	Id        types.String `tfsdk:"id"`
-	OrgUnitId types.String `tfsdk:"org_unit_id"`
-	DriveId   types.String `tfsdk:"drive_id"`
+	Parent    types.String   `tfsdk:"parent"`
+	Id        types.String   `tfsdk:"id"`
+	OrgUnitId types.String   `tfsdk:"org_unit_id"`
+	DriveId   types.String   `tfsdk:"drive_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *gdriveOrgUnitMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,6 +89,14 @@ Some things to note:
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -97,12 +106,12 @@ func (r *gdriveOrgUnitMembershipResource) Configure(ctx context.Context, req res
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -116,7 +125,13 @@ func (r *gdriveOrgUnitMembershipResource) Create(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(plan.move()...)
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(plan.move(ctx, r.client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -129,20 +144,30 @@ func (r *gdriveOrgUnitMembershipResource) Read(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
 	id := state.Id.ValueString()
 	found := false
-	memberships, err := gsmcibeta.ListOrgUnitMemberships(id[0:strings.Index(id, "/memberships")], "customers/my_customer", "", "", 1)
+	memberships, err := client.listOrgUnitMemberships(ctx, id[0:strings.Index(id, "/memberships")], "customers/my_customer", "", "")
+	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list Org Unit memberships, got error: %s", err))
+		return
+	}
 	for i := range memberships {
-		if i.Name == id {
+		if memberships[i].Name == id {
 			found = true
 			break
 		}
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list Org Unit memberships, got error: %s", e))
-		return
-	}
 	if !found {
 		state.Parent = types.StringUnknown()
 	}
@@ -155,7 +180,13 @@ func (r *gdriveOrgUnitMembershipResource) Update(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(plan.move()...)
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(plan.move(ctx, r.client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
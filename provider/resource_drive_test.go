@@ -43,6 +43,13 @@ func TestAccDrive(t *testing.T) {
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.drive_members_only", restrictionsBefore),
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.copy_requires_writer_permission", restrictionsBefore),
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.domain_users_only", restrictionsBefore),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.sharing_folders_requires_organizer_permission", restrictionsBefore),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_readers", restrictionsBefore),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_writers", restrictionsBefore),
+					resource.TestCheckResourceAttrSet("gdrive_drive.drive_simple", "color_rgb"),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_appearance", "color_rgb", "#4986e7"),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_appearance", "hidden", restrictionsBefore),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_force_destroy", "force_destroy", "true"),
 				),
 			},
 			// 2 - ImportState testing
@@ -62,6 +69,11 @@ func TestAccDrive(t *testing.T) {
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.drive_members_only", restrictionsAfter),
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.copy_requires_writer_permission", restrictionsAfter),
 					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.domain_users_only", restrictionsAfter),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.sharing_folders_requires_organizer_permission", restrictionsAfter),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_readers", restrictionsAfter),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_restrictions", "restrictions.download_restricted_for_writers", restrictionsAfter),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_appearance", "color_rgb", "#4986e7"),
+					resource.TestCheckResourceAttr("gdrive_drive.drive_appearance", "hidden", restrictionsAfter),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -84,6 +96,32 @@ resource "gdrive_drive" "drive_restrictions" {
     drive_members_only              = %s
     copy_requires_writer_permission = %s
     domain_users_only               = %s
+
+    sharing_folders_requires_organizer_permission = %s
+    download_restricted_for_readers               = %s
+    download_restricted_for_writers               = %s
   }
-}`, name, name, restrictions, restrictions, restrictions, restrictions)
+}
+
+resource "gdrive_drive" "drive_appearance" {
+  name                    = "%s"
+  use_domain_admin_access = true
+  color_rgb               = "#4986e7"
+  hidden                  = %s
+}
+
+resource "gdrive_drive" "drive_force_destroy" {
+  name                    = "%s"
+  use_domain_admin_access = true
+  force_destroy           = true
+}
+
+# The file is not deleted by Terraform, so the Shared Drive is not empty when it is destroyed.
+resource "gdrive_file" "abandoned" {
+  name            = "%s"
+  mime_type       = "application/vnd.google-apps.folder"
+  parent          = gdrive_drive.drive_force_destroy.drive_id
+  drive_id        = gdrive_drive.drive_force_destroy.drive_id
+  delete_behavior = "abandon"
+}`, name, name, restrictions, restrictions, restrictions, restrictions, restrictions, restrictions, restrictions, name, restrictions, name, name)
 }
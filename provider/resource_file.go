@@ -20,23 +20,31 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/api/googleapi"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &gdriveFileResource{}
 var _ resource.ResourceWithImportState = &gdriveFileResource{}
+var _ resource.ResourceWithModifyPlan = &gdriveFileResource{}
 
-const fieldsFile = "parents,mimeType,driveId,name,id"
+const fieldsFile = "parents,mimeType,driveId,name,id,trashed,md5Checksum,description,properties,appProperties,folderColorRgb,starred,writersCanShare,copyRequiresWriterPermission"
 
 func newFile() resource.Resource {
 	return &gdriveFileResource{}
@@ -44,19 +52,35 @@ func newFile() resource.Resource {
 
 // gdriveFileResource defines the resource implementation.
 type gdriveFileResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdriveFileResourceModel describes the resource data model.
 type gdriveFileResourceModel struct {
-	Parent         types.String `tfsdk:"parent"`
-	Name           types.String `tfsdk:"name"`
-	MimeType       types.String `tfsdk:"mime_type"`
-	Id             types.String `tfsdk:"id"`
-	FileId         types.String `tfsdk:"file_id"`
-	MimeTypeSource types.String `tfsdk:"mime_type_source"`
-	DriveId        types.String `tfsdk:"drive_id"`
-	Content        types.String `tfsdk:"content"`
+	Parent                       types.String   `tfsdk:"parent"`
+	Name                         types.String   `tfsdk:"name"`
+	MimeType                     types.String   `tfsdk:"mime_type"`
+	Id                           types.String   `tfsdk:"id"`
+	FileId                       types.String   `tfsdk:"file_id"`
+	MimeTypeSource               types.String   `tfsdk:"mime_type_source"`
+	DriveId                      types.String   `tfsdk:"drive_id"`
+	Content                      types.String   `tfsdk:"content"`
+	ContentString                types.String   `tfsdk:"content_string"`
+	ContentBase64                types.String   `tfsdk:"content_base64"`
+	ContentMd5                   types.String   `tfsdk:"content_md5"`
+	SourceHash                   types.String   `tfsdk:"source_hash"`
+	ChunkSize                    types.Int64    `tfsdk:"chunk_size"`
+	Description                  types.String   `tfsdk:"description"`
+	Properties                   types.Map      `tfsdk:"properties"`
+	AppProperties                types.Map      `tfsdk:"app_properties"`
+	FolderColorRgb               types.String   `tfsdk:"folder_color_rgb"`
+	Starred                      types.Bool     `tfsdk:"starred"`
+	WritersCanShare              types.Bool     `tfsdk:"writers_can_share"`
+	CopyRequiresWriterPermission types.Bool     `tfsdk:"copy_requires_writer_permission"`
+	DeleteBehavior               types.String   `tfsdk:"delete_behavior"`
+	DeletionProtection           types.Bool     `tfsdk:"deletion_protection"`
+	ImpersonateSubject           types.String   `tfsdk:"impersonate_subject"`
+	Timeouts                     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *gdriveFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,9 +89,16 @@ func (r *gdriveFileResource) Metadata(ctx context.Context, req resource.Metadata
 
 func (r *gdriveFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Creates a file or folder with the given MIME type and optionally uploads or imports a local file.",
+		MarkdownDescription: `Creates a file or folder with the given MIME type and optionally uploads or imports a local file.
+If the file is moved to the trash outside of Terraform, it is removed from the state and planned for creation.`,
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
+			"delete_behavior": rsDeleteBehavior(`What happens to the file when the resource is destroyed:
+* delete: The file is deleted permanently. The descendants of a folder are deleted as well. This is the default.
+* trash: The file is moved to the trash and can be restored from there.
+* abandon: The file is only removed from the Terraform state.`, deleteBehaviorDelete, deleteBehaviorTrash, deleteBehaviorDelete, deleteBehaviorAbandon),
+			"deletion_protection": rsDeletionProtection(),
 			"file_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the file.",
@@ -98,11 +129,118 @@ func (r *gdriveFileResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"content": schema.StringAttribute{
 				MarkdownDescription: `Path to a file to upload.
 
-The provider does not check the content of the file for updates.
+The file is uploaded again if its content (source_hash) changes or if the content of the file in Drive (content_md5) no longer matches it.`,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("content_string"),
+						path.MatchRoot("content_base64"),
+					}...),
+				},
+			},
+			"content_string": schema.StringAttribute{
+				MarkdownDescription: "Content to upload as a UTF-8 string, e.g. rendered with templatefile().",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("content"),
+						path.MatchRoot("content_base64"),
+					}...),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Content to upload as a base64 encoded string. Use this for binary content.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("content"),
+						path.MatchRoot("content_string"),
+					}...),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A short description of the file.",
+				Optional:            true,
+			},
+			"properties": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Custom key-value pairs that are visible to all apps.",
+				Optional:            true,
+			},
+			"app_properties": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: `Custom key-value pairs that are private to the app (OAuth client) of the provider's credentials.
+
+Use this to tag managed files, e.g. with the owning team or a cost center.`,
+				Optional: true,
+			},
+			"folder_color_rgb": schema.StringAttribute{
+				MarkdownDescription: `The color of a folder as an RGB hex string, e.g. "#4986e7".
+
+Use one of the colors of the folder color palette. Other colors are changed to the closest color of the palette by Google Drive.`,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"starred": schema.BoolAttribute{
+				MarkdownDescription: "Whether the file is starred by the impersonated user.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"writers_can_share": schema.BoolAttribute{
+				MarkdownDescription: "Whether users with only writer permission can modify the file's permissions. Not populated for items in Shared Drives.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copy_requires_writer_permission": schema.BoolAttribute{
+				MarkdownDescription: "Whether the options to copy, print, or download the file should be disabled for readers and commenters.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chunk_size": schema.Int64Attribute{
+				MarkdownDescription: `Size in bytes of the chunks in which the content is uploaded. It is rounded up to a multiple of 256 KiB (262144 bytes).
 
-If you need to upload/import a new version of a file, you need to supply a different file name.`,
+Content that is larger than one chunk is uploaded in a resumable upload session. Chunks that fail with a retryable error are sent again, instead of restarting the whole upload.
+The progress of the upload is logged at the INFO level. Set to 0 to upload the content in a single request.`,
 				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(googleapi.DefaultUploadChunkSize),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
+			"content_md5": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: `MD5 checksum of the content of the file in Drive.
+
+Google Docs, Sheets, Slides, etc. and folders do not have a checksum.`,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MD5 hash of the content to upload (content, content_string or content_base64). It is computed during plan.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -113,12 +251,12 @@ func (r *gdriveFileResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -126,31 +264,75 @@ func (r *gdriveFileResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+func (r *gdriveFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	plan := &gdriveFileResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := &gdriveFileResourceModel{}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceHash, err := plan.sourceHash()
+	if err != nil {
+		if plan.Content.IsNull() {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to hash content, got error: %s", err))
+			return
+		}
+		// The local file may not exist yet, if it is created by another resource during apply.
+		tflog.Debug(ctx, "Unable to hash local file (content), the hash is computed during apply", map[string]interface{}{
+			"content": plan.Content.ValueString(),
+			"error":   err.Error(),
+		})
+	}
+	plan.SourceHash = sourceHash
+	if plan.contentChanged(state) {
+		if plan.MimeType.IsUnknown() || isGoogleWorkspaceType(plan.MimeType.ValueString()) {
+			plan.ContentMd5 = types.StringUnknown()
+		} else {
+			plan.ContentMd5 = plan.SourceHash
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
 func (r *gdriveFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	plan := &gdriveFileResourceModel{}
 	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	fileReq := plan.toRequest()
 	fileReq.Parents = []string{plan.Parent.ValueString()}
-	var content *os.File
-	var err error
-	if !plan.Content.IsNull() {
-		content, err = os.Open(plan.Content.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to open local file (content), got error: %s", err))
-			return
-		}
+	content, err := plan.openContent()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to open content, got error: %s", err))
+		return
+	}
+	if content != nil {
 		defer content.Close()
 	}
-	f, err := gsmdrive.CreateFile(fileReq, content, false, false, false, "", "", plan.MimeTypeSource.ValueString(), fieldsFile)
+	f, err := client.createFile(ctx, fileReq, content, plan.MimeTypeSource.ValueString(), fieldsFile, plan.ChunkSize.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create file, got error: %s", err))
 		return
 	}
 	plan.Id = types.StringValue(f.Id)
 	plan.FileId = plan.Id
+	plan.ContentMd5 = stringValueOrNull(f.Md5Checksum)
+	plan.setComputedMetadata(f)
+	resp.Diagnostics.Append(plan.setSourceHash()...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -160,11 +342,26 @@ func (r *gdriveFileResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	f, err := gsmdrive.GetFile(state.Id.ValueString(), fieldsFile, "")
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	f, err := client.getFile(ctx, state.Id.ValueString(), fieldsFile)
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get file, got error: %s", err))
 		return
 	}
+	if f.Trashed {
+		removeFromState(ctx, resp)
+		return
+	}
 	if len(f.Parents) != 0 {
 		state.Parent = types.StringValue(f.Parents[0])
 	}
@@ -174,6 +371,12 @@ func (r *gdriveFileResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.Name = types.StringValue(f.Name)
 	state.FileId = types.StringValue(f.Id)
 	state.MimeType = types.StringValue(f.MimeType)
+	state.ContentMd5 = stringValueOrNull(f.Md5Checksum)
+	resp.Diagnostics.Append(state.populateMetadata(f)...)
+	setDeleteDefaults(&state.DeleteBehavior, &state.DeletionProtection)
+	if state.ChunkSize.IsNull() {
+		state.ChunkSize = types.Int64Value(googleapi.DefaultUploadChunkSize)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -183,6 +386,12 @@ func (r *gdriveFileResource) Update(ctx context.Context, req resource.UpdateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveFileResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -192,24 +401,28 @@ func (r *gdriveFileResource) Update(ctx context.Context, req resource.UpdateRequ
 	var removeParents string
 	var err error
 	fileReq := plan.toRequest()
+	plan.clearRemovedMetadata(fileReq, state)
 	if !plan.Parent.Equal(state.Parent) {
 		removeParents = state.Parent.ValueString()
 		addParents = plan.Parent.ValueString()
 	}
-	var content *os.File
-	if !plan.Content.Equal(state.Content) && !plan.Content.IsNull() {
-		content, err = os.Open(plan.Content.ValueString())
+	var content io.ReadSeekCloser
+	if plan.contentChanged(state) {
+		content, err = plan.openContent()
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to open local file (content), got error: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to open content, got error: %s", err))
 			return
 		}
 		defer content.Close()
 	}
-	_, err = gsmdrive.UpdateFile(plan.Id.ValueString(), addParents, removeParents, "", "", fieldsFile, fileReq, content, false, false)
+	f, err := client.updateFile(ctx, plan.Id.ValueString(), addParents, removeParents, fieldsFile, fileReq, content, plan.ChunkSize.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update file, got error: %s", err))
 		return
 	}
+	plan.ContentMd5 = stringValueOrNull(f.Md5Checksum)
+	plan.setComputedMetadata(f)
+	resp.Diagnostics.Append(plan.setSourceHash()...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -219,7 +432,28 @@ func (r *gdriveFileResource) Delete(ctx context.Context, req resource.DeleteRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := gsmdrive.DeleteFile(plan.Id.ValueString())
+	resp.Diagnostics.Append(checkDeletionProtection(plan.DeletionProtection, plan.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Delete, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch plan.DeleteBehavior.ValueString() {
+	case deleteBehaviorAbandon:
+		abandon(ctx, plan.Id)
+		return
+	case deleteBehaviorTrash:
+		err := client.trashFile(ctx, plan.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to trash file, got error: %s", err))
+		}
+		return
+	}
+	err := client.deleteFile(ctx, plan.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file, got error: %s", err))
 		return
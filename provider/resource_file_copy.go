@@ -0,0 +1,278 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/drive/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &gdriveFileCopyResource{}
+var _ resource.ResourceWithImportState = &gdriveFileCopyResource{}
+
+const fieldsFileCopy = "parents,mimeType,driveId,name,id,trashed"
+
+func newFileCopy() resource.Resource {
+	return &gdriveFileCopyResource{}
+}
+
+// gdriveFileCopyResource defines the resource implementation.
+type gdriveFileCopyResource struct {
+	client *gdriveClient
+}
+
+// gdriveFileCopyResourceModel describes the resource data model.
+type gdriveFileCopyResourceModel struct {
+	Id                      types.String   `tfsdk:"id"`
+	FileId                  types.String   `tfsdk:"file_id"`
+	SourceId                types.String   `tfsdk:"source_id"`
+	Parent                  types.String   `tfsdk:"parent"`
+	Name                    types.String   `tfsdk:"name"`
+	MimeType                types.String   `tfsdk:"mime_type"`
+	DriveId                 types.String   `tfsdk:"drive_id"`
+	IgnoreDefaultVisibility types.Bool     `tfsdk:"ignore_default_visibility"`
+	KeepRevisionForever     types.Bool     `tfsdk:"keep_revision_forever"`
+	ImpersonateSubject      types.String   `tfsdk:"impersonate_subject"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *gdriveFileCopyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file_copy"
+}
+
+func (r *gdriveFileCopyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Creates a copy of a file, e.g. of a Google Docs or Sheets template.
+
+The copy is created once. Later changes to the source file are not copied again.
+If the copy is moved to the trash outside of Terraform, it is removed from the state and planned for creation.`,
+		Attributes: map[string]schema.Attribute{
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
+			"file_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the copy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "The file_id of the file to copy. Folders cannot be copied.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent": schema.StringAttribute{
+				MarkdownDescription: "The file_id of the parent of the copy.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the copy.",
+				Required:            true,
+			},
+			"mime_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type of the copy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"drive_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the Shared Drive the copy is in.",
+			},
+			"ignore_default_visibility": schema.BoolAttribute{
+				MarkdownDescription: `Whether to ignore the domain's default visibility settings for the created file.
+
+Domain administrators can choose to make all uploaded files visible to the domain by default; this parameter bypasses that behavior for the request.`,
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"keep_revision_forever": schema.BoolAttribute{
+				MarkdownDescription: "Whether to set the 'keepForever' field in the new head revision. This is only applicable to files with binary content in Google Drive.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *gdriveFileCopyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gdriveClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+func (r *gdriveFileCopyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := &gdriveFileCopyResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fileReq := &drive.File{
+		Name:    plan.Name.ValueString(),
+		Parents: []string{plan.Parent.ValueString()},
+	}
+	f, err := client.copyFile(ctx, plan.SourceId.ValueString(), fieldsFileCopy, plan.IgnoreDefaultVisibility.ValueBool(), plan.KeepRevisionForever.ValueBool(), fileReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to copy file, got error: %s", err))
+		return
+	}
+	plan.populate(f)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveFileCopyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := &gdriveFileCopyResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	f, err := client.getFile(ctx, state.Id.ValueString(), fieldsFileCopy)
+	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get file, got error: %s", err))
+		return
+	}
+	if f.Trashed {
+		removeFromState(ctx, resp)
+		return
+	}
+	state.populate(f)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *gdriveFileCopyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := &gdriveFileCopyResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := &gdriveFileCopyResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var addParents string
+	var removeParents string
+	if !plan.Parent.Equal(state.Parent) {
+		removeParents = state.Parent.ValueString()
+		addParents = plan.Parent.ValueString()
+	}
+	fileReq := &drive.File{
+		Name: plan.Name.ValueString(),
+	}
+	f, err := client.updateFile(ctx, plan.Id.ValueString(), addParents, removeParents, fieldsFileCopy, fileReq, nil, 0)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update file, got error: %s", err))
+		return
+	}
+	plan.populate(f)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveFileCopyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := &gdriveFileCopyResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := client.deleteFile(ctx, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file, got error: %s", err))
+		return
+	}
+}
+
+func (r *gdriveFileCopyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The source of a copy cannot be read from the API, so it is part of the import identifier.
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: 'source_id,file_id'. Got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccFileCopy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// 1 - Create and Read testing
+			{
+				Config: testAccFileCopyResourceConfig("copy", "gdrive_drive.drive.drive_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_file_copy.copy", "name", "copy"),
+					resource.TestCheckResourceAttr("gdrive_file_copy.copy", "mime_type", "application/vnd.google-apps.spreadsheet"),
+					resource.TestCheckResourceAttrPair("gdrive_file_copy.copy", "drive_id", "gdrive_drive.drive", "drive_id"),
+				),
+			},
+			// 2 - ImportState testing
+			{
+				ResourceName: "gdrive_file_copy.copy",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["gdrive_file_copy.copy"]
+					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["source_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+			// 3 - Rename and move
+			{
+				Config: testAccFileCopyResourceConfig("copy_renamed", "gdrive_file.folder.file_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_file_copy.copy", "name", "copy_renamed"),
+					resource.TestCheckResourceAttrPair("gdrive_file_copy.copy", "parent", "gdrive_file.folder", "file_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFileCopyResourceConfig(name, parent string) string {
+	return fmt.Sprintf(`
+resource "gdrive_drive" "drive" {
+  name                    = "file copy test"
+  use_domain_admin_access = true
+}
+
+resource "gdrive_file" "folder" {
+  name      = "folder"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_file" "template" {
+  name      = "template"
+  mime_type = "application/vnd.google-apps.spreadsheet"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_file_copy" "copy" {
+  name      = "%s"
+  source_id = gdrive_file.template.file_id
+  parent    = %s
+}
+`, name, parent)
+}
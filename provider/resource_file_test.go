@@ -18,6 +18,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package provider
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"testing"
@@ -39,6 +41,14 @@ c3,c4
 	if err != nil {
 		panic(err)
 	}
+	updatedContent := `
+h1,h2
+c5,c6
+`
+	md5Sum := md5.Sum(d1)
+	nameMd5Sum := md5.Sum([]byte(name))
+	renamedMd5Sum := md5.Sum([]byte(renamed))
+	updatedMd5Sum := md5.Sum([]byte(updatedContent))
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -51,6 +61,13 @@ c3,c4
 					resource.TestCheckResourceAttr("gdrive_file.folder", "mime_type", "application/vnd.google-apps.folder"),
 					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "name", name),
 					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "mime_type", "text/plain"),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "content_md5", hex.EncodeToString(md5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "source_hash", hex.EncodeToString(md5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "description", name),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "app_properties.team", name),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content_string", "content_md5", hex.EncodeToString(nameMd5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content_base64", "content_md5", hex.EncodeToString(nameMd5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content_base64", "chunk_size", "262144"),
 					resource.TestCheckResourceAttr("gdrive_file.import_csv", "name", name),
 					resource.TestCheckResourceAttr("gdrive_file.import_csv", "mime_type", "application/vnd.google-apps.spreadsheet"),
 					resource.TestCheckResourceAttr("gdrive_file.empty_spreadsheet", "name", name),
@@ -71,13 +88,32 @@ c3,c4
 					resource.TestCheckResourceAttr("gdrive_file.folder", "mime_type", "application/vnd.google-apps.folder"),
 					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "name", renamed),
 					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "mime_type", "text/plain"),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "description", renamed),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "app_properties.team", renamed),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content_string", "content_md5", hex.EncodeToString(renamedMd5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content_base64", "content_md5", hex.EncodeToString(renamedMd5Sum[:])),
 					resource.TestCheckResourceAttr("gdrive_file.import_csv", "name", renamed),
 					resource.TestCheckResourceAttr("gdrive_file.import_csv", "mime_type", "application/vnd.google-apps.spreadsheet"),
 					resource.TestCheckResourceAttr("gdrive_file.empty_spreadsheet", "name", renamed),
 					resource.TestCheckResourceAttr("gdrive_file.empty_spreadsheet", "mime_type", "application/vnd.google-apps.spreadsheet"),
 				),
 			},
-			// 4 - Delete files
+			// 4 - Update the content of the local file
+			{
+				PreConfig: func() {
+					err := os.WriteFile(testFile, []byte(updatedContent), 0644)
+					if err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccFileResourceConfig(renamed, testFile, "true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "content_md5", hex.EncodeToString(updatedMd5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.file_with_content", "source_hash", hex.EncodeToString(updatedMd5Sum[:])),
+					resource.TestCheckResourceAttr("gdrive_file.import_csv", "source_hash", hex.EncodeToString(updatedMd5Sum[:])),
+				),
+			},
+			// 5 - Delete files
 			{
 				Config: testAccFileResourceConfig(renamed, testFile, "false"),
 			},
@@ -105,11 +141,15 @@ resource "gdrive_file" "empty_spreadsheet" {
 }
 
 resource "gdrive_file" "file_with_content" {
-  name      = "%s"
-  mime_type = "text/plain"
-  drive_id  = gdrive_file.folder.drive_id
-  parent    = gdrive_file.folder.file_id
-  content   = "%s"
+  name        = "%s"
+  mime_type   = "text/plain"
+  drive_id    = gdrive_file.folder.drive_id
+  parent      = gdrive_file.folder.file_id
+  content     = "%s"
+  description = "%s"
+  app_properties = {
+    team = "%s"
+  }
 }
 
 resource "gdrive_file" "import_csv" {
@@ -117,10 +157,28 @@ resource "gdrive_file" "import_csv" {
   mime_type        = "application/vnd.google-apps.spreadsheet"
   mime_type_source = "text/csv"
   content          = "%s"
+  delete_behavior  = "trash"
   drive_id  	   = gdrive_file.folder.drive_id
   parent           = gdrive_file.folder.file_id
 }
-`, name, name, name, testFile, name, testFile)
+
+resource "gdrive_file" "file_with_content_string" {
+  name           = "%s"
+  mime_type      = "text/plain"
+  drive_id       = gdrive_file.folder.drive_id
+  parent         = gdrive_file.folder.file_id
+  content_string = "%s"
+}
+
+resource "gdrive_file" "file_with_content_base64" {
+  name           = "%s"
+  mime_type      = "text/plain"
+  drive_id       = gdrive_file.folder.drive_id
+  parent         = gdrive_file.folder.file_id
+  content_base64 = base64encode("%s")
+  chunk_size     = 262144
+}
+`, name, name, name, testFile, name, name, name, testFile, name, name, name, name)
 	}
 	return fmt.Sprintf(`resource "gdrive_drive" "drive" {
   name                    = "file test"
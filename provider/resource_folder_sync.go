@@ -0,0 +1,339 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &gdriveFolderSyncResource{}
+var _ resource.ResourceWithModifyPlan = &gdriveFolderSyncResource{}
+var _ resource.ResourceWithImportState = &gdriveFolderSyncResource{}
+
+func newFolderSync() resource.Resource {
+	return &gdriveFolderSyncResource{}
+}
+
+// gdriveFolderSyncResource defines the resource implementation.
+type gdriveFolderSyncResource struct {
+	client *gdriveClient
+}
+
+// gdriveFolderSyncResourceModel describes the resource data model.
+type gdriveFolderSyncResourceModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	FolderId           types.String   `tfsdk:"folder_id"`
+	SourceDir          types.String   `tfsdk:"source_dir"`
+	DeleteOrphans      types.Bool     `tfsdk:"delete_orphans"`
+	OrphanBehavior     types.String   `tfsdk:"orphan_behavior"`
+	Files              types.Map      `tfsdk:"files"`
+	Folders            types.Map      `tfsdk:"folders"`
+	DeleteBehavior     types.String   `tfsdk:"delete_behavior"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ImpersonateSubject types.String   `tfsdk:"impersonate_subject"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *gdriveFolderSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder_sync"
+}
+
+func (r *gdriveFolderSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Destroying the resource removes whole folders, so it is protected by default.
+	deletionProtection := rsDeletionProtection()
+	deletionProtection.Default = booldefault.StaticBool(true)
+	deletionProtection.MarkdownDescription += `
+Defaults to true, because destroying the resource removes the synced top level files and folders from the Drive folder,
+including any content that was added to the synced folders outside of Terraform.`
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Mirrors a local directory tree into a Drive folder.
+
+Subfolders are created, new files are uploaded and files whose MD5 checksum differs from the local file are uploaded again.
+The local directory is read during plan, so changes to it are detected without renaming anything.`,
+		Attributes: map[string]schema.Attribute{
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
+			"folder_id": schema.StringAttribute{
+				MarkdownDescription: "The file_id of the Drive folder the local directory is synced into.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_dir": schema.StringAttribute{
+				MarkdownDescription: "Path to the local directory.",
+				Required:            true,
+			},
+			"delete_orphans": schema.BoolAttribute{
+				MarkdownDescription: `If set to true, files and folders in the Drive folder that do not exist in the local directory are removed.
+If a folder contains several files with the same name, all but one of them are removed as well.`,
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"orphan_behavior": rsDeleteBehavior(`What happens to orphans and duplicates, if delete_orphans is true:
+* trash: They are moved to the trash and can be restored from there. This is the default.
+* delete: They are deleted permanently.`, deleteBehaviorTrash, deleteBehaviorTrash, deleteBehaviorDelete),
+			"delete_behavior": rsDeleteBehavior(`What happens to the synced files and folders when the resource is destroyed:
+* trash: The top level files and folders of source_dir are moved to the trash and can be restored from there. This is the default.
+* delete: The top level files and folders of source_dir are deleted permanently.
+* abandon: They are kept and the resource is only removed from the state.
+
+Removing a top level folder removes everything in it, including any content that was added to the synced folders outside of Terraform.
+Orphans are handled by orphan_behavior.`, deleteBehaviorTrash, deleteBehaviorTrash, deleteBehaviorDelete, deleteBehaviorAbandon),
+			"deletion_protection": deletionProtection,
+			"files": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The synced files, keyed by their path relative to source_dir.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the file.",
+						},
+						"md5_checksum": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "MD5 checksum of the content of the file in Drive.",
+						},
+					},
+				},
+			},
+			"folders": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the synced folders, keyed by their path relative to source_dir.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *gdriveFolderSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gdriveClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+func (r *gdriveFolderSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	plan := &gdriveFolderSyncResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := &gdriveFolderSyncResourceModel{}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Files = types.MapUnknown(syncedFileType)
+	plan.Folders = types.MapUnknown(types.StringType)
+	if !plan.SourceDir.IsUnknown() && plan.FolderId.Equal(state.FolderId) {
+		local, err := readLocalTree(plan.SourceDir.ValueString())
+		if err != nil {
+			// The local directory may not exist yet, if it is created by another resource during apply.
+			tflog.Debug(ctx, "Unable to read local directory (source_dir), it is synced during apply", map[string]interface{}{
+				"source_dir": plan.SourceDir.ValueString(),
+				"error":      err.Error(),
+			})
+		} else {
+			inSync, diags := state.inSync(ctx, local)
+			resp.Diagnostics.Append(diags...)
+			if inSync {
+				plan.Files = state.Files
+				plan.Folders = state.Folders
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *gdriveFolderSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := &gdriveFolderSyncResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = plan.FolderId
+	resp.Diagnostics.Append(plan.sync(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveFolderSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := &gdriveFolderSyncResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	folder, err := client.withoutRetryOn(http.StatusNotFound).getFile(ctx, state.FolderId.ValueString(), "id,trashed")
+	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get folder, got error: %s", err))
+		return
+	}
+	if folder.Trashed {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(state.refresh(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.setDefaults()
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *gdriveFolderSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := &gdriveFolderSyncResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(plan.sync(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveFolderSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := &gdriveFolderSyncResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, state.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.DeleteBehavior.ValueString() == deleteBehaviorAbandon {
+		abandon(ctx, state.Id)
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Removing the top level entries removes everything below them as well.
+	topLevel := map[string]string{}
+	files := map[string]syncedFileModel{}
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &files, false)...)
+	folders := map[string]string{}
+	resp.Diagnostics.Append(state.Folders.ElementsAs(ctx, &folders, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for p, f := range files {
+		if parentPath(p) == "" {
+			topLevel[p] = f.FileId.ValueString()
+		}
+	}
+	for p, id := range folders {
+		if parentPath(p) == "" {
+			topLevel[p] = id
+		}
+	}
+	paths := make([]string, 0, len(topLevel))
+	for p := range topLevel {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		err := removeFile(ctx, client, topLevel[p], state.DeleteBehavior.ValueString())
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove %s, got error: %s", p, err))
+			return
+		}
+	}
+}
+
+func (r *gdriveFolderSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The local directory cannot be read from the API, so it is part of the import identifier.
+	// It is the last part, because it may contain commas.
+	idParts := strings.SplitN(req.ID, ",", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: 'folder_id,source_dir'. Got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("folder_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_dir"), idParts[1])...)
+}
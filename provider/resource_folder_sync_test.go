@@ -0,0 +1,129 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccFolderSync(t *testing.T) {
+	sourceDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(sourceDir, "sub"), 0755)
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(filepath.Join(sourceDir, "top.txt"), []byte("top"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(filepath.Join(sourceDir, "sub", "nested.txt"), []byte("nested"), 0644)
+	if err != nil {
+		panic(err)
+	}
+	changedMd5Sum := md5.Sum([]byte("changed"))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// 1 - Create and Read testing
+			{
+				Config: testAccFolderSyncResourceConfig(sourceDir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_folder_sync.sync", "files.%", "2"),
+					resource.TestCheckResourceAttr("gdrive_folder_sync.sync", "folders.%", "1"),
+					resource.TestCheckResourceAttrSet("gdrive_folder_sync.sync", "files.sub/nested.txt.file_id"),
+					resource.TestCheckResourceAttrSet("gdrive_folder_sync.sync", "folders.sub"),
+				),
+			},
+			// 2 - Change a file, add a file and remove a file
+			{
+				PreConfig: func() {
+					err := os.WriteFile(filepath.Join(sourceDir, "top.txt"), []byte("changed"), 0644)
+					if err != nil {
+						panic(err)
+					}
+					err = os.WriteFile(filepath.Join(sourceDir, "sub", "new.txt"), []byte("new"), 0644)
+					if err != nil {
+						panic(err)
+					}
+					err = os.Remove(filepath.Join(sourceDir, "sub", "nested.txt"))
+					if err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccFolderSyncResourceConfig(sourceDir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_folder_sync.sync", "files.%", "2"),
+					resource.TestCheckResourceAttr("gdrive_folder_sync.sync", "files.top.txt.md5_checksum", hex.EncodeToString(changedMd5Sum[:])),
+					resource.TestCheckResourceAttrSet("gdrive_folder_sync.sync", "files.sub/new.txt.file_id"),
+					resource.TestCheckNoResourceAttr("gdrive_folder_sync.sync", "files.sub/nested.txt.file_id"),
+				),
+			},
+			// 3 - No changes
+			{
+				Config:   testAccFolderSyncResourceConfig(sourceDir),
+				PlanOnly: true,
+			},
+			// 4 - ImportState testing
+			{
+				ResourceName: "gdrive_folder_sync.sync",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["gdrive_folder_sync.sync"]
+					return fmt.Sprintf("%s,%s", rs.Primary.ID, rs.Primary.Attributes["source_dir"]), nil
+				},
+				ImportStateVerify: true,
+				// delete_orphans and deletion_protection cannot be read from the API and are set to their defaults
+				ImportStateVerifyIgnore: []string{"delete_orphans", "deletion_protection"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFolderSyncResourceConfig(sourceDir string) string {
+	return fmt.Sprintf(`
+resource "gdrive_drive" "drive" {
+  name                    = "folder sync test"
+  use_domain_admin_access = true
+}
+
+resource "gdrive_file" "folder" {
+  name      = "synced"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_folder_sync" "sync" {
+  folder_id      = gdrive_file.folder.file_id
+  source_dir     = "%s"
+  delete_orphans      = true
+  orphan_behavior     = "delete"
+  deletion_protection = false
+}
+`, sourceDir)
+}
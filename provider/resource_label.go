@@ -23,8 +23,8 @@ import (
 	"net/http"
 	"reflect"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -45,7 +45,7 @@ func newLabel() resource.Resource {
 
 // gdriveLabelResource defines the resource implementation.
 type gdriveLabelResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 func (propertiesModel *gdriveLabelResourcePropertiesModel) populate(properties *drivelabels.GoogleAppsDriveLabelsV2LabelProperties) {
@@ -74,6 +74,7 @@ type gdriveLabelResourceModel struct {
 	LanguageCode   types.String                        `tfsdk:"language_code"`
 	LabelType      types.String                        `tfsdk:"label_type"`
 	UseAdminAccess types.Bool                          `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value                      `tfsdk:"timeouts"`
 }
 
 func (r *gdriveLabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -139,6 +140,12 @@ The following values are accepted:
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"life_cycle": lifeCycleRS(),
 			"properties": schema.SingleNestedBlock{
 				MarkdownDescription: "Basic properties of the label.",
@@ -163,12 +170,12 @@ func (r *gdriveLabelResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -183,6 +190,12 @@ func (r *gdriveLabelResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	labelReq := &drivelabels.GoogleAppsDriveLabelsV2Label{
 		LabelType: plan.LabelType.ValueString(),
 		Properties: &drivelabels.GoogleAppsDriveLabelsV2LabelProperties{
@@ -193,7 +206,7 @@ func (r *gdriveLabelResource) Create(ctx context.Context, req resource.CreateReq
 
 	languageCode := plan.LanguageCode.ValueString()
 	useAdminAccess := plan.UseAdminAccess.ValueBool()
-	l, err := gsmdrivelabels.CreateLabel(labelReq, languageCode, "*", useAdminAccess)
+	l, err := r.client.createLabel(ctx, labelReq, languageCode, "*", useAdminAccess)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create label, got error: %s", err))
 		return
@@ -216,7 +229,7 @@ func (r *gdriveLabelResource) Create(ctx context.Context, req resource.CreateReq
 				LanguageCode:   languageCode,
 				UseAdminAccess: useAdminAccess,
 			}
-			l, err = gsmdrivelabels.Publish(l.Name, "*", publishReq)
+			l, err = r.client.publishLabel(ctx, l.Name, "*", publishReq)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish label, got error: %s", err))
 				return
@@ -228,7 +241,7 @@ func (r *gdriveLabelResource) Create(ctx context.Context, req resource.CreateReq
 				UseAdminAccess: useAdminAccess,
 				DisabledPolicy: lifecycle.DisabledPolicy,
 			}
-			l, err = gsmdrivelabels.Disable(l.Name, "*", disableReq)
+			l, err = r.client.disableLabel(ctx, l.Name, "*", disableReq)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable label, got error: %s", err))
 				return
@@ -244,7 +257,19 @@ func (r *gdriveLabelResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(state.populate(ctx)...)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
+	diags = state.populate(ctx, client)
+	if hasNotFound(diags) {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -257,6 +282,12 @@ func (r *gdriveLabelResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -281,7 +312,7 @@ func (r *gdriveLabelResource) Update(ctx context.Context, req resource.UpdateReq
 			req.UpdateLabel.Properties.ForceSendFields = append(req.UpdateLabel.Properties.ForceSendFields, "Title")
 		}
 		updateLabelRequest.Requests = append(updateLabelRequest.Requests, req)
-		_, err := gsmdrivelabels.Delta(labelId, "*", updateLabelRequest)
+		_, err := r.client.deltaLabel(ctx, labelId, "*", updateLabelRequest)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update label, got error: %s", err))
 			return
@@ -302,7 +333,7 @@ func (r *gdriveLabelResource) Update(ctx context.Context, req resource.UpdateReq
 			LanguageCode:   plan.getLanguageCode(),
 			UseAdminAccess: plan.getUseAdminAccess(),
 		}
-		_, err := gsmdrivelabels.Enable(labelId, "*", enableReq)
+		_, err := r.client.enableLabel(ctx, labelId, "*", enableReq)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable label, got error: %s", err))
 			return
@@ -313,7 +344,7 @@ func (r *gdriveLabelResource) Update(ctx context.Context, req resource.UpdateReq
 			LanguageCode:   plan.getLanguageCode(),
 			UseAdminAccess: plan.getUseAdminAccess(),
 		}
-		_, err := gsmdrivelabels.Publish(labelId, "*", publishReq)
+		_, err := r.client.publishLabel(ctx, labelId, "*", publishReq)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish label, got error: %s", err))
 			return
@@ -325,7 +356,7 @@ func (r *gdriveLabelResource) Update(ctx context.Context, req resource.UpdateReq
 			UseAdminAccess: plan.getUseAdminAccess(),
 			DisabledPolicy: lifecyclePlan.DisabledPolicy,
 		}
-		_, err := gsmdrivelabels.Disable(labelId, "*", disable)
+		_, err := r.client.disableLabel(ctx, labelId, "*", disable)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable label, got error: %s", err))
 			return
@@ -340,7 +371,13 @@ func (r *gdriveLabelResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := gsmdrivelabels.DeleteLabel(gsmhelpers.EnsurePrefix(state.LabelId.ValueString(), "labels/"), "", state.UseAdminAccess.ValueBool())
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.deleteLabel(ctx, gsmhelpers.EnsurePrefix(state.LabelId.ValueString(), "labels/"), "", state.UseAdminAccess.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete label, got error: %s", err))
 		return
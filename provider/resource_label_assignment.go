@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -42,7 +42,7 @@ func newLabelAssignment() resource.Resource {
 
 // gdriveLabelAssignmentResource defines the resource implementation.
 type gdriveLabelAssignmentResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelFieldModel struct {
@@ -53,10 +53,12 @@ type gdriveLabelFieldModel struct {
 
 // gdriveLabelAssignmentResourceModel describes the resource data model.
 type gdriveLabelAssignmentResourceModel struct {
-	FileId  types.String             `tfsdk:"file_id"`
-	LabelId types.String             `tfsdk:"label_id"`
-	Id      types.String             `tfsdk:"id"`
-	Fields  []*gdriveLabelFieldModel `tfsdk:"fields"`
+	FileId             types.String             `tfsdk:"file_id"`
+	LabelId            types.String             `tfsdk:"label_id"`
+	Id                 types.String             `tfsdk:"id"`
+	Fields             []*gdriveLabelFieldModel `tfsdk:"fields"`
+	ImpersonateSubject types.String             `tfsdk:"impersonate_subject"`
+	Timeouts           timeouts.Value           `tfsdk:"timeouts"`
 }
 
 func (r *gdriveLabelAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,7 +69,8 @@ func (r *gdriveLabelAssignmentResource) Schema(ctx context.Context, req resource
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Sets a label on a Drive object.",
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
 			"file_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the file to assign the label to.",
 				Required:            true,
@@ -84,6 +87,14 @@ func (r *gdriveLabelAssignmentResource) Schema(ctx context.Context, req resource
 			},
 			"fields": labelAssignmentFields(),
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -93,12 +104,12 @@ func (r *gdriveLabelAssignmentResource) Configure(ctx context.Context, req resou
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -113,16 +124,22 @@ func (r *gdriveLabelAssignmentResource) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	mockState := &gdriveLabelAssignmentResourceModel{
 		FileId:  plan.FileId,
 		LabelId: plan.LabelId,
 		Id:      types.StringValue(combineId(plan.FileId.ValueString(), plan.LabelId.ValueString())),
 	}
-	resp.Diagnostics.Append(mockState.populate(ctx)...)
+	resp.Diagnostics.Append(mockState.populate(ctx, client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setFieldDiffs(plan, mockState, ctx)...)
+	resp.Diagnostics.Append(setFieldDiffs(client, plan, mockState, ctx)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -136,7 +153,19 @@ func (r *gdriveLabelAssignmentResource) Read(ctx context.Context, req resource.R
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(state.populate(ctx)...)
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	diags = state.populate(ctx, client)
+	if hasNotFound(diags) {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -149,12 +178,18 @@ func (r *gdriveLabelAssignmentResource) Update(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelAssignmentResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setFieldDiffs(plan, state, ctx)...)
+	resp.Diagnostics.Append(setFieldDiffs(client, plan, state, ctx)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -167,7 +202,13 @@ func (r *gdriveLabelAssignmentResource) Delete(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := gsmdrive.ModifyLabels(state.FileId.ValueString(), "", &drive.ModifyLabelsRequest{
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, err := client.modifyLabels(ctx, state.FileId.ValueString(), "", &drive.ModifyLabelsRequest{
 		LabelModifications: []*drive.LabelModification{
 			{
 				LabelId:     state.LabelId.ValueString(),
@@ -23,8 +23,8 @@ import (
 	"net/http"
 	"reflect"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -43,7 +43,7 @@ func newLabelDateField() resource.Resource {
 
 // gdriveLabelDateFieldResource defines the resource implementation.
 type gdriveLabelDateFieldResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelDateOptionsRSModel struct {
@@ -60,6 +60,7 @@ type gdriveLabelDateFieldResourceModel struct {
 	QueryKey       types.String                      `tfsdk:"query_key"`
 	LanguageCode   types.String                      `tfsdk:"language_code"`
 	UseAdminAccess types.Bool                        `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value                    `tfsdk:"timeouts"`
 }
 
 func (fieldModel *gdriveLabelDateFieldResourceModel) toField() (field *drivelabels.GoogleAppsDriveLabelsV2Field) {
@@ -181,6 +182,12 @@ When not specified, values in the default configured language are used.`,
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"life_cycle": lifeCycleRS(),
 			"properties": fieldProperties(),
 			"date_options": schema.SingleNestedBlock{
@@ -206,12 +213,12 @@ func (r *gdriveLabelDateFieldResource) Configure(ctx context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -226,7 +233,13 @@ func (r *gdriveLabelDateFieldResource) Create(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(createLabelField(plan)...)
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(createLabelField(ctx, r.client, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -239,8 +252,19 @@ func (r *gdriveLabelDateFieldResource) Read(ctx context.Context, req resource.Re
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	field, err := populateField(state)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
+	field, err := populateField(ctx, client, state)
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get label field, got error: %s", err))
 		return
 	}
@@ -258,6 +282,12 @@ func (r *gdriveLabelDateFieldResource) Update(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelDateFieldResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -279,7 +309,7 @@ func (r *gdriveLabelDateFieldResource) Update(ctx context.Context, req resource.
 		}
 		updateLabelRequest.Requests = append(updateLabelRequest.Requests, updateDateOptionsRequest)
 	}
-	_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
+	_, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update date field, got error: %s", err))
 		return
@@ -293,7 +323,13 @@ func (r *gdriveLabelDateFieldResource) Delete(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(deleteLabelField(state)...)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(deleteLabelField(ctx, r.client, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
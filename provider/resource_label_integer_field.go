@@ -22,8 +22,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -42,7 +42,7 @@ func newLabelIntegerField() resource.Resource {
 
 // gdriveLabelIntegerFieldResource defines the resource implementation.
 type gdriveLabelIntegerFieldResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelIntegerFieldResourceModel struct {
@@ -54,6 +54,7 @@ type gdriveLabelIntegerFieldResourceModel struct {
 	QueryKey       types.String                      `tfsdk:"query_key"`
 	LanguageCode   types.String                      `tfsdk:"language_code"`
 	UseAdminAccess types.Bool                        `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value                    `tfsdk:"timeouts"`
 }
 
 func (fieldModel *gdriveLabelIntegerFieldResourceModel) toField() (field *drivelabels.GoogleAppsDriveLabelsV2Field) {
@@ -162,6 +163,12 @@ When not specified, values in the default configured language are used.`,
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"life_cycle": lifeCycleRS(),
 			"properties": fieldProperties(),
 		},
@@ -174,12 +181,12 @@ func (r *gdriveLabelIntegerFieldResource) Configure(ctx context.Context, req res
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -194,7 +201,13 @@ func (r *gdriveLabelIntegerFieldResource) Create(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(createLabelField(plan)...)
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(createLabelField(ctx, r.client, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -207,8 +220,19 @@ func (r *gdriveLabelIntegerFieldResource) Read(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := populateField(state)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
+	_, err := populateField(ctx, client, state)
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get label field, got error: %s", err))
 		return
 	}
@@ -221,13 +245,19 @@ func (r *gdriveLabelIntegerFieldResource) Update(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelIntegerFieldResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	updateLabelRequest := newUpdateFieldRequest(plan, state)
-	_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
+	_, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update integer field, got error: %s", err))
 		return
@@ -241,7 +271,13 @@ func (r *gdriveLabelIntegerFieldResource) Delete(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(deleteLabelField(state)...)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(deleteLabelField(ctx, r.client, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
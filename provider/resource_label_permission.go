@@ -24,8 +24,8 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -38,34 +38,35 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &gdriveLabelPermissionResourceModel{}
-var _ resource.ResourceWithImportState = &gdriveLabelPermissionResourceModel{}
+var _ resource.Resource = &gdriveLabelPermissionResource{}
+var _ resource.ResourceWithImportState = &gdriveLabelPermissionResource{}
 
 func newLabelPermission() resource.Resource {
-	return &gdriveLabelPermissionResourceModel{}
+	return &gdriveLabelPermissionResource{}
 }
 
-// gdriveLabelPermissionResourceModel defines the resource implementation.
+// gdriveLabelPermissionResource defines the resource implementation.
 type gdriveLabelPermissionResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdriveLabelPermissionResourceModel describes the resource data model.
 type gdriveLabelPermissionResourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Parent         types.String `tfsdk:"parent"`
-	Email          types.String `tfsdk:"email"`
-	Audience       types.String `tfsdk:"audience"`
-	Role           types.String `tfsdk:"role"`
-	UseAdminAccess types.Bool   `tfsdk:"use_admin_access"`
+	Id             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Parent         types.String   `tfsdk:"parent"`
+	Email          types.String   `tfsdk:"email"`
+	Audience       types.String   `tfsdk:"audience"`
+	Role           types.String   `tfsdk:"role"`
+	UseAdminAccess types.Bool     `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
-func (r *gdriveLabelPermissionResourceModel) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+func (r *gdriveLabelPermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_label_permission"
 }
 
-func (r *gdriveLabelPermissionResourceModel) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *gdriveLabelPermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Updates a Label's permissions.
 
@@ -134,6 +135,14 @@ The magic value of 'audiences/default' may be used to apply the role to the defa
 * EDITOR     - Editors can make any update including deleting the label which also deletes the associated Drive item metadata. Implies APPLIER.`,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -143,12 +152,12 @@ func (r *gdriveLabelPermissionResource) Configure(ctx context.Context, req resou
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -170,13 +179,19 @@ func (permissionModel *gdriveLabelPermissionResourceModel) toPermission() *drive
 	return permission
 }
 
-func (r *gdriveLabelPermissionResourceModel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *gdriveLabelPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	plan := &gdriveLabelPermissionResourceModel{}
 	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	p, err := gsmdrivelabels.CreateLabelPermission(gsmhelpers.EnsurePrefix(plan.Parent.ValueString(), "labels/"), "", plan.UseAdminAccess.ValueBool(), plan.toPermission())
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p, err := r.client.createLabelPermission(ctx, gsmhelpers.EnsurePrefix(plan.Parent.ValueString(), "labels/"), "", plan.UseAdminAccess.ValueBool(), plan.toPermission())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create label permission, got error: %s", err))
 		return
@@ -186,16 +201,33 @@ func (r *gdriveLabelPermissionResourceModel) Create(ctx context.Context, req res
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func (r *gdriveLabelPermissionResourceModel) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+func (r *gdriveLabelPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	state := &gdriveLabelPermissionResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
 	name := state.Id.ValueString()
-	currentP, err := gsmdrivelabels.ListLabelPermissions(gsmhelpers.EnsurePrefix(state.Parent.ValueString(), "labels/"), "", state.UseAdminAccess.ValueBool(), 1)
-	for i := range currentP {
+	currentP, err := client.listLabelPermissions(ctx, gsmhelpers.EnsurePrefix(state.Parent.ValueString(), "labels/"), "", state.UseAdminAccess.ValueBool())
+	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions on label, got error: %s", err))
+		return
+	}
+	found := false
+	for _, i := range currentP {
 		if i.Name == name {
+			found = true
 			if i.Email != "" {
 				state.Email = types.StringValue(i.Email)
 			}
@@ -205,20 +237,26 @@ func (r *gdriveLabelPermissionResourceModel) Read(ctx context.Context, req resou
 			state.Role = types.StringValue(i.Role)
 		}
 	}
-	e := <-err
-	if e != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions on label, got error: %s", e))
+	if !found {
+		removeFromState(ctx, resp)
+		return
 	}
 	state.Name = state.Id
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func (r *gdriveLabelPermissionResourceModel) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+func (r *gdriveLabelPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	plan := &gdriveLabelPermissionResourceModel{}
 	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelPermissionResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -236,19 +274,25 @@ func (r *gdriveLabelPermissionResourceModel) Update(ctx context.Context, req res
 			},
 		},
 	}
-	_, err := gsmdrivelabels.BatchUpdateLabelPermissions(parent, "", updateReq)
+	_, err := r.client.batchUpdateLabelPermissions(ctx, parent, "", updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update permissions on label, got error: %s", err))
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func (r *gdriveLabelPermissionResourceModel) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+func (r *gdriveLabelPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	state := &gdriveLabelPermissionResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	useAdminAccess := state.UseAdminAccess.ValueBool()
 	deleteReq := &drivelabels.GoogleAppsDriveLabelsV2BatchDeleteLabelPermissionsRequest{
 		UseAdminAccess: useAdminAccess,
@@ -259,10 +303,14 @@ func (r *gdriveLabelPermissionResourceModel) Delete(ctx context.Context, req res
 			},
 		},
 	}
-	gsmdrivelabels.BatchDeleteLabelPermissions(gsmhelpers.EnsurePrefix(state.Parent.ValueString(), "labels/"), deleteReq)
+	err := r.client.batchDeleteLabelPermissions(ctx, gsmhelpers.EnsurePrefix(state.Parent.ValueString(), "labels/"), deleteReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete permission on label, got error: %s", err))
+		return
+	}
 }
 
-func (r *gdriveLabelPermissionResourceModel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+func (r *gdriveLabelPermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, ",")
 	if len(idParts) < 2 {
 		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: 'useAdminAccess,name'. Got: %q", req.ID))
@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -42,7 +42,7 @@ func newLabelPolicy() resource.Resource {
 
 // gdriveLabelPolicyResource defines the resource implementation.
 type gdriveLabelPolicyResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelPolicyLabelModel struct {
@@ -52,9 +52,11 @@ type gdriveLabelPolicyLabelModel struct {
 
 // gdriveLabelPolicyResourceModel describes the resource data model.
 type gdriveLabelPolicyResourceModel struct {
-	FileId types.String                   `tfsdk:"file_id"`
-	Id     types.String                   `tfsdk:"id"`
-	Labels []*gdriveLabelPolicyLabelModel `tfsdk:"labels"`
+	FileId             types.String                   `tfsdk:"file_id"`
+	Id                 types.String                   `tfsdk:"id"`
+	Labels             []*gdriveLabelPolicyLabelModel `tfsdk:"labels"`
+	ImpersonateSubject types.String                   `tfsdk:"impersonate_subject"`
+	Timeouts           timeouts.Value                 `tfsdk:"timeouts"`
 }
 
 func (r *gdriveLabelPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,7 +67,8 @@ func (r *gdriveLabelPolicyResource) Schema(ctx context.Context, req resource.Sch
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Enforces a set of labels on a Drive object.",
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
 			"file_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the file to assign the label(s) to.",
 				Required:            true,
@@ -87,6 +90,14 @@ func (r *gdriveLabelPolicyResource) Schema(ctx context.Context, req resource.Sch
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -96,12 +107,12 @@ func (r *gdriveLabelPolicyResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -116,15 +127,21 @@ func (r *gdriveLabelPolicyResource) Create(ctx context.Context, req resource.Cre
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	mockState := &gdriveLabelPolicyResourceModel{
 		FileId: plan.FileId,
 		Id:     plan.FileId,
 	}
-	resp.Diagnostics.Append(mockState.populate(ctx)...)
+	resp.Diagnostics.Append(mockState.populate(ctx, client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setLabelDiffs(plan, mockState, ctx)...)
+	resp.Diagnostics.Append(setLabelDiffs(client, plan, mockState, ctx)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -138,7 +155,19 @@ func (r *gdriveLabelPolicyResource) Read(ctx context.Context, req resource.ReadR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(state.populate(ctx)...)
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	diags = state.populate(ctx, client)
+	if hasNotFound(diags) {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -151,12 +180,18 @@ func (r *gdriveLabelPolicyResource) Update(ctx context.Context, req resource.Upd
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelPolicyResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setLabelDiffs(plan, state, ctx)...)
+	resp.Diagnostics.Append(setLabelDiffs(client, plan, state, ctx)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -169,6 +204,12 @@ func (r *gdriveLabelPolicyResource) Delete(ctx context.Context, req resource.Del
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	modLabelsReq := &drive.ModifyLabelsRequest{
 		LabelModifications: []*drive.LabelModification{},
 	}
@@ -178,7 +219,7 @@ func (r *gdriveLabelPolicyResource) Delete(ctx context.Context, req resource.Del
 			RemoveLabel: true,
 		})
 	}
-	_, err := gsmdrive.ModifyLabels(state.Id.ValueString(), "", modLabelsReq)
+	_, err := client.modifyLabels(ctx, state.Id.ValueString(), "", modLabelsReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Configuration Error", fmt.Sprintf("Unable to remove label assignment(s), got error: %s", err))
 		return
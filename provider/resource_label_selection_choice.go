@@ -24,8 +24,8 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
@@ -46,7 +46,7 @@ func newLabelSelectionChoice() resource.Resource {
 
 // gdriveLabelSelectionChoiceResource defines the resource implementation.
 type gdriveLabelSelectionChoiceResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelChoiceBadgeColorConfigModel struct {
@@ -76,6 +76,7 @@ type gdriveLabelSelectionChoiceResourceModel struct {
 	LabelId        types.String                        `tfsdk:"label_id"`
 	LanguageCode   types.String                        `tfsdk:"language_code"`
 	UseAdminAccess types.Bool                          `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value                      `tfsdk:"timeouts"`
 }
 
 func (choiceModel *gdriveLabelSelectionChoiceResourceModel) toChoice() (choice *drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoice) {
@@ -185,6 +186,12 @@ When not specified, values in the default configured language are used.`,
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"life_cycle": lifeCycleRS(),
 			"properties": schema.SingleNestedBlock{
 				MarkdownDescription: "Basic properties of the choice.",
@@ -258,12 +265,12 @@ func (r *gdriveLabelSelectionChoiceResource) Configure(ctx context.Context, req
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -278,6 +285,12 @@ func (r *gdriveLabelSelectionChoiceResource) Create(ctx context.Context, req res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	labelId := plan.LabelId.ValueString()
 	fieldId := plan.FieldId.ValueString()
 	updateLabelRequest := newUpdateLabelRequest(plan)
@@ -289,7 +302,7 @@ func (r *gdriveLabelSelectionChoiceResource) Create(ctx context.Context, req res
 			},
 		},
 	}
-	updatedLabel, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(labelId, "labels/"), "*", updateLabelRequest)
+	updatedLabel, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(labelId, "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create selection choice, got error: %s", err))
 		return
@@ -320,6 +333,13 @@ func (r *gdriveLabelSelectionChoiceResource) Read(ctx context.Context, req resou
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
 	idString := state.Id.ValueString()
 	ids := strings.Split(idString, "/")
 	if len(ids) != 3 {
@@ -329,17 +349,23 @@ func (r *gdriveLabelSelectionChoiceResource) Read(ctx context.Context, req resou
 	labelId := ids[0]
 	fieldId := ids[1]
 	choiceId := ids[2]
-	l, err := gsmdrivelabels.GetLabel(gsmhelpers.EnsurePrefix(labelId, "labels/"), state.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", state.UseAdminAccess.ValueBool())
+	l, err := client.getLabel(ctx, gsmhelpers.EnsurePrefix(labelId, "labels/"), state.LanguageCode.ValueString(), "LABEL_VIEW_FULL", "*", state.UseAdminAccess.ValueBool())
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get label field, got error: %s", err))
 		return
 	}
 	c := state.toChoice()
+	found := false
 	for i := range l.Fields {
 		if l.Fields[i].Id == fieldId {
 			if l.Fields[i].SelectionOptions != nil {
 				for j := range l.Fields[i].SelectionOptions.Choices {
 					if l.Fields[i].SelectionOptions.Choices[j].Id == choiceId {
+						found = true
 						if state.LifeCycle != nil {
 							state.LifeCycle.populate(l.Fields[i].SelectionOptions.Choices[j].Lifecycle)
 						}
@@ -361,6 +387,10 @@ func (r *gdriveLabelSelectionChoiceResource) Read(ctx context.Context, req resou
 			break
 		}
 	}
+	if !found {
+		removeFromState(ctx, resp)
+		return
+	}
 	state.LabelId = types.StringValue(labelId)
 	state.FieldId = types.StringValue(fieldId)
 	state.ChoiceId = types.StringValue(choiceId)
@@ -373,6 +403,12 @@ func (r *gdriveLabelSelectionChoiceResource) Update(ctx context.Context, req res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelSelectionChoiceResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -411,7 +447,7 @@ func (r *gdriveLabelSelectionChoiceResource) Update(ctx context.Context, req res
 			})
 		}
 	}
-	_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(plan.LabelId.ValueString(), "labels/"), "*", updateLabelRequest)
+	_, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(plan.LabelId.ValueString(), "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update selection choice, got error: %s", err))
 		return
@@ -425,6 +461,12 @@ func (r *gdriveLabelSelectionChoiceResource) Delete(ctx context.Context, req res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	updateLabelRequest := newUpdateLabelRequest(state)
 	updateLabelRequest.Requests = []*drivelabels.GoogleAppsDriveLabelsV2DeltaUpdateLabelRequestRequest{
 		{
@@ -434,7 +476,7 @@ func (r *gdriveLabelSelectionChoiceResource) Delete(ctx context.Context, req res
 			},
 		},
 	}
-	_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(state.LabelId.ValueString(), "labels/"), "*", updateLabelRequest)
+	_, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(state.LabelId.ValueString(), "labels/"), "*", updateLabelRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete selection choice, got error: %s", err))
 		return
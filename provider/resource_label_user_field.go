@@ -23,8 +23,8 @@ import (
 	"net/http"
 	"reflect"
 
-	"github.com/hanneshayashi/gsm/gsmdrivelabels"
 	"github.com/hanneshayashi/gsm/gsmhelpers"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -43,7 +43,7 @@ func newLabelUserField() resource.Resource {
 
 // gdriveLabelUserFieldResource defines the resource implementation.
 type gdriveLabelUserFieldResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 type gdriveLabelUserOptionsRSModel struct {
@@ -60,6 +60,7 @@ type gdriveLabelUserFieldResourceModel struct {
 	QueryKey       types.String                      `tfsdk:"query_key"`
 	LanguageCode   types.String                      `tfsdk:"language_code"`
 	UseAdminAccess types.Bool                        `tfsdk:"use_admin_access"`
+	Timeouts       timeouts.Value                    `tfsdk:"timeouts"`
 }
 
 func (fieldModel *gdriveLabelUserFieldResourceModel) toField() (field *drivelabels.GoogleAppsDriveLabelsV2Field) {
@@ -178,6 +179,12 @@ When not specified, values in the default configured language are used.`,
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"life_cycle": lifeCycleRS(),
 			"properties": fieldProperties(),
 			"user_options": schema.SingleNestedBlock{
@@ -196,12 +203,12 @@ func (r *gdriveLabelUserFieldResource) Configure(ctx context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -216,7 +223,13 @@ func (r *gdriveLabelUserFieldResource) Create(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(createLabelField(plan)...)
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(createLabelField(ctx, r.client, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -229,8 +242,19 @@ func (r *gdriveLabelUserFieldResource) Read(ctx context.Context, req resource.Re
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	field, err := populateField(state)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.client.withoutRetryOn(http.StatusNotFound)
+	field, err := populateField(ctx, client, state)
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get label field, got error: %s", err))
 		return
 	}
@@ -250,6 +274,12 @@ func (r *gdriveLabelUserFieldResource) Update(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdriveLabelUserFieldResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -269,7 +299,7 @@ func (r *gdriveLabelUserFieldResource) Update(ctx context.Context, req resource.
 		updateLabelRequest.Requests = append(updateLabelRequest.Requests, updateUserOptionsRequest)
 	}
 	if len(updateLabelRequest.Requests) > 0 {
-		_, err := gsmdrivelabels.Delta(gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
+		_, err := r.client.deltaLabel(ctx, gsmhelpers.EnsurePrefix(plan.getLabelId(), "labels/"), "*", updateLabelRequest)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user field, got error: %s", err))
 			return
@@ -284,7 +314,13 @@ func (r *gdriveLabelUserFieldResource) Delete(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(deleteLabelField(state)...)
+	ctx, cancel, diags := withTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(deleteLabelField(ctx, r.client, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
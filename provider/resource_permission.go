@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -46,23 +46,25 @@ func newPermission() resource.Resource {
 
 // gdrivePermissionResource defines the resource implementation.
 type gdrivePermissionResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdrivePermissionResourceModel describes the resource data model.
 type gdrivePermissionResourceModel struct {
-	FileId                types.String `tfsdk:"file_id"`
-	PermissionId          types.String `tfsdk:"permission_id"`
-	EmailMessage          types.String `tfsdk:"email_message"`
-	Id                    types.String `tfsdk:"id"`
-	Type                  types.String `tfsdk:"type"`
-	Domain                types.String `tfsdk:"domain"`
-	EmailAddress          types.String `tfsdk:"email_address"`
-	Role                  types.String `tfsdk:"role"`
-	SendNotificationEmail types.Bool   `tfsdk:"send_notification_email"`
-	UseDomainAdminAccess  types.Bool   `tfsdk:"use_domain_admin_access"`
-	TransferOwnership     types.Bool   `tfsdk:"transfer_ownership"`
-	MoveToNewOwnersRoot   types.Bool   `tfsdk:"move_to_new_owners_root"`
+	FileId                types.String   `tfsdk:"file_id"`
+	PermissionId          types.String   `tfsdk:"permission_id"`
+	EmailMessage          types.String   `tfsdk:"email_message"`
+	Id                    types.String   `tfsdk:"id"`
+	Type                  types.String   `tfsdk:"type"`
+	Domain                types.String   `tfsdk:"domain"`
+	EmailAddress          types.String   `tfsdk:"email_address"`
+	Role                  types.String   `tfsdk:"role"`
+	SendNotificationEmail types.Bool     `tfsdk:"send_notification_email"`
+	UseDomainAdminAccess  types.Bool     `tfsdk:"use_domain_admin_access"`
+	TransferOwnership     types.Bool     `tfsdk:"transfer_ownership"`
+	MoveToNewOwnersRoot   types.Bool     `tfsdk:"move_to_new_owners_root"`
+	ImpersonateSubject    types.String   `tfsdk:"impersonate_subject"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *gdrivePermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,7 +75,8 @@ func (r *gdrivePermissionResource) Schema(ctx context.Context, req resource.Sche
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Grants a permission on a file/folder or Shared Drive.",
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
 			"file_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the file or Shared Drive.",
 				Required:            true,
@@ -144,6 +147,14 @@ func (r *gdrivePermissionResource) Schema(ctx context.Context, req resource.Sche
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -153,12 +164,12 @@ func (r *gdrivePermissionResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -173,6 +184,12 @@ func (r *gdrivePermissionResource) Create(ctx context.Context, req resource.Crea
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	fileID := plan.FileId.ValueString()
 	permissionReq := &drive.Permission{
 		Domain:       plan.Domain.ValueString(),
@@ -180,7 +197,7 @@ func (r *gdrivePermissionResource) Create(ctx context.Context, req resource.Crea
 		Role:         plan.Role.ValueString(),
 		Type:         plan.Type.ValueString(),
 	}
-	p, err := gsmdrive.CreatePermission(fileID, plan.EmailMessage.ValueString(), fieldsPermission, plan.UseDomainAdminAccess.ValueBool(), plan.SendNotificationEmail.ValueBool(), plan.TransferOwnership.ValueBool(), plan.MoveToNewOwnersRoot.ValueBool(), permissionReq)
+	p, err := client.createPermission(ctx, fileID, plan.EmailMessage.ValueString(), fieldsPermission, plan.UseDomainAdminAccess.ValueBool(), plan.SendNotificationEmail.ValueBool(), plan.TransferOwnership.ValueBool(), plan.MoveToNewOwnersRoot.ValueBool(), permissionReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set permission on file, got error: %s", err))
 		return
@@ -196,13 +213,24 @@ func (r *gdrivePermissionResource) Read(ctx context.Context, req resource.ReadRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
 	fileId, permissionId, err := splitId(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("Unable to use ID, got error: %s", err))
 		return
 	}
-	p, err := gsmdrive.GetPermission(fileId, permissionId, fieldsPermission, state.UseDomainAdminAccess.ValueBool())
+	p, err := client.getPermission(ctx, fileId, permissionId, fieldsPermission, state.UseDomainAdminAccess.ValueBool())
 	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read permission on file, got error: %s", err))
 		return
 	}
@@ -225,6 +253,12 @@ func (r *gdrivePermissionResource) Update(ctx context.Context, req resource.Upda
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdrivePermissionResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
@@ -234,7 +268,7 @@ func (r *gdrivePermissionResource) Update(ctx context.Context, req resource.Upda
 		permissionReq := &drive.Permission{
 			Role: plan.Role.ValueString(),
 		}
-		_, err := gsmdrive.UpdatePermission(plan.FileId.ValueString(), plan.PermissionId.ValueString(), fieldsPermission, plan.UseDomainAdminAccess.ValueBool(), false, permissionReq)
+		_, err := client.updatePermission(ctx, plan.FileId.ValueString(), plan.PermissionId.ValueString(), fieldsPermission, plan.UseDomainAdminAccess.ValueBool(), false, permissionReq)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update permission on file, got error: %s", err))
 			return
@@ -249,7 +283,13 @@ func (r *gdrivePermissionResource) Delete(ctx context.Context, req resource.Dele
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := gsmdrive.DeletePermission(plan.FileId.ValueString(), plan.PermissionId.ValueString(), plan.UseDomainAdminAccess.ValueBool())
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Delete, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := client.deletePermission(ctx, plan.FileId.ValueString(), plan.PermissionId.ValueString(), plan.UseDomainAdminAccess.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete permission, got error: %s", err))
 		return
@@ -22,7 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/hanneshayashi/gsm/gsmdrive"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -43,7 +43,7 @@ func newPermissionPolicy() resource.Resource {
 
 // gdrivePermissionPolicyResource defines the resource implementation.
 type gdrivePermissionPolicyResource struct {
-	client *http.Client
+	client *gdriveClient
 }
 
 // gdrivePermissionPolicyResourceModel describes the resource data model.
@@ -65,6 +65,8 @@ type gdrivePermissionPolicyResourceModel struct {
 	Id                   types.String                                     `tfsdk:"id"`
 	Permissions          []*gdrivePermissionPolicyPermissionResourceModel `tfsdk:"permissions"`
 	UseDomainAdminAccess types.Bool                                       `tfsdk:"use_domain_admin_access"`
+	ImpersonateSubject   types.String                                     `tfsdk:"impersonate_subject"`
+	Timeouts             timeouts.Value                                   `tfsdk:"timeouts"`
 }
 
 func (r *gdrivePermissionPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -81,7 +83,8 @@ It is HIGHLY recommended that you import the resource and make sure that the own
 
 **Important**: On a *destroy*, this resource will preserve the owner and organizer permissions!`,
 		Attributes: map[string]schema.Attribute{
-			"id": rsId(),
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
 			"file_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the file or Shared Drive.",
 				Required:            true,
@@ -148,6 +151,14 @@ It is HIGHLY recommended that you import the resource and make sure that the own
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -157,12 +168,12 @@ func (r *gdrivePermissionPolicyResource) Configure(ctx context.Context, req reso
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*gdriveClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -177,15 +188,21 @@ func (r *gdrivePermissionPolicyResource) Create(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	mockState := &gdrivePermissionPolicyResourceModel{
 		FileId: plan.FileId,
 		Id:     plan.FileId,
 	}
-	resp.Diagnostics.Append(mockState.populate(ctx)...)
+	resp.Diagnostics.Append(mockState.populate(ctx, client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setPermissionDiffs(plan, mockState)...)
+	resp.Diagnostics.Append(setPermissionDiffs(ctx, client, plan, mockState)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -199,7 +216,19 @@ func (r *gdrivePermissionPolicyResource) Read(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(state.populate(ctx)...)
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	diags = state.populate(ctx, client)
+	if hasNotFound(diags) {
+		removeFromState(ctx, resp)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -222,12 +251,18 @@ func (r *gdrivePermissionPolicyResource) Update(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state := &gdrivePermissionPolicyResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(setPermissionDiffs(plan, state)...)
+	resp.Diagnostics.Append(setPermissionDiffs(ctx, client, plan, state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -240,10 +275,16 @@ func (r *gdrivePermissionPolicyResource) Delete(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Delete, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	for i := range plan.Permissions {
 		role := plan.Permissions[i].Role.ValueString()
 		if role != "owner" && role != "organizer" {
-			_, err := gsmdrive.DeletePermission(plan.FileId.ValueString(), plan.Permissions[i].PermissionId.ValueString(), plan.UseDomainAdminAccess.ValueBool())
+			err := client.deletePermission(ctx, plan.FileId.ValueString(), plan.Permissions[i].PermissionId.ValueString(), plan.UseDomainAdminAccess.ValueBool())
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete permission, got error: %s", err))
 				return
@@ -0,0 +1,284 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/api/drive/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &gdriveShortcutResource{}
+var _ resource.ResourceWithImportState = &gdriveShortcutResource{}
+
+const (
+	mimeTypeShortcut = "application/vnd.google-apps.shortcut"
+	fieldsShortcut   = "parents,driveId,name,id,trashed,shortcutDetails(targetId,targetMimeType,targetResourceKey)"
+)
+
+func newShortcut() resource.Resource {
+	return &gdriveShortcutResource{}
+}
+
+// gdriveShortcutResource defines the resource implementation.
+type gdriveShortcutResource struct {
+	client *gdriveClient
+}
+
+// gdriveShortcutResourceModel describes the resource data model.
+type gdriveShortcutResourceModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	ShortcutId         types.String   `tfsdk:"shortcut_id"`
+	Parent             types.String   `tfsdk:"parent"`
+	Name               types.String   `tfsdk:"name"`
+	TargetId           types.String   `tfsdk:"target_id"`
+	TargetMimeType     types.String   `tfsdk:"target_mime_type"`
+	TargetResourceKey  types.String   `tfsdk:"target_resource_key"`
+	DriveId            types.String   `tfsdk:"drive_id"`
+	ImpersonateSubject types.String   `tfsdk:"impersonate_subject"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *gdriveShortcutResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shortcut"
+}
+
+func (r *gdriveShortcutResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Creates a shortcut to a file or folder.
+
+If the target of the shortcut is deleted or trashed, the shortcut is planned for replacement.
+If the shortcut itself is moved to the trash outside of Terraform, it is removed from the state and planned for creation.`,
+		Attributes: map[string]schema.Attribute{
+			"id":                  rsId(),
+			"impersonate_subject": rsImpersonateSubject(),
+			"shortcut_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the shortcut.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent": schema.StringAttribute{
+				MarkdownDescription: "The file_id of the parent.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the shortcut.",
+				Required:            true,
+			},
+			"target_id": schema.StringAttribute{
+				MarkdownDescription: "The file_id of the file or folder the shortcut points to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_mime_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type of the target.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_resource_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The resource key of the target, if it has one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"drive_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the Shared Drive the shortcut is in.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *gdriveShortcutResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gdriveClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *gdriveClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+func (r *gdriveShortcutResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := &gdriveShortcutResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Create, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fileReq := &drive.File{
+		MimeType: mimeTypeShortcut,
+		Name:     plan.Name.ValueString(),
+		Parents:  []string{plan.Parent.ValueString()},
+		ShortcutDetails: &drive.FileShortcutDetails{
+			TargetId: plan.TargetId.ValueString(),
+		},
+	}
+	f, err := client.createFile(ctx, fileReq, nil, "", fieldsShortcut, 0)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create shortcut, got error: %s", err))
+		return
+	}
+	plan.populate(f)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveShortcutResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := &gdriveShortcutResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Read, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client = client.withoutRetryOn(http.StatusNotFound)
+	f, err := client.getFile(ctx, state.Id.ValueString(), fieldsShortcut)
+	if err != nil {
+		if isNotFound(err) {
+			removeFromState(ctx, resp)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get shortcut, got error: %s", err))
+		return
+	}
+	if f.Trashed {
+		removeFromState(ctx, resp)
+		return
+	}
+	if f.ShortcutDetails == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("File %s is not a shortcut", f.Id))
+		return
+	}
+	state.populate(f)
+	target, err := client.getFile(ctx, state.TargetId.ValueString(), "id,trashed")
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get target of shortcut, got error: %s", err))
+		return
+	}
+	if err != nil || target.Trashed {
+		// A null target_id forces the replacement of the dangling shortcut.
+		tflog.Warn(ctx, "Target of shortcut no longer exists, planning replacement", map[string]interface{}{
+			"shortcut_id": state.ShortcutId.ValueString(),
+			"target_id":   state.TargetId.ValueString(),
+		})
+		state.TargetId = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *gdriveShortcutResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := &gdriveShortcutResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, plan.Timeouts.Update, r.client, plan.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := &gdriveShortcutResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var addParents string
+	var removeParents string
+	if !plan.Parent.Equal(state.Parent) {
+		removeParents = state.Parent.ValueString()
+		addParents = plan.Parent.ValueString()
+	}
+	fileReq := &drive.File{
+		Name: plan.Name.ValueString(),
+	}
+	f, err := client.updateFile(ctx, plan.Id.ValueString(), addParents, removeParents, fieldsShortcut, fileReq, nil, 0)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update shortcut, got error: %s", err))
+		return
+	}
+	plan.populate(f)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *gdriveShortcutResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := &gdriveShortcutResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel, client, diags := withTimeoutAndClient(ctx, state.Timeouts.Delete, r.client, state.ImpersonateSubject)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := client.deleteFile(ctx, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete shortcut, got error: %s", err))
+		return
+	}
+}
+
+func (r *gdriveShortcutResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2021-2023 Hannes Hayashi
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccShortcut(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// 1 - Create and Read testing
+			{
+				Config: testAccShortcutResourceConfig("shortcut", "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_shortcut.shortcut", "name", "shortcut"),
+					resource.TestCheckResourceAttr("gdrive_shortcut.shortcut", "target_mime_type", "application/vnd.google-apps.folder"),
+					resource.TestCheckResourceAttrPair("gdrive_shortcut.shortcut", "target_id", "gdrive_file.first", "file_id"),
+					resource.TestCheckResourceAttrPair("gdrive_shortcut.shortcut", "drive_id", "gdrive_drive.drive", "drive_id"),
+				),
+			},
+			// 2 - ImportState testing
+			{
+				ResourceName:      "gdrive_shortcut.shortcut",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// 3 - Rename and move
+			{
+				Config: testAccShortcutResourceConfig("shortcut_renamed", "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gdrive_shortcut.shortcut", "name", "shortcut_renamed"),
+					resource.TestCheckResourceAttrPair("gdrive_shortcut.shortcut", "parent", "gdrive_file.second", "file_id"),
+				),
+			},
+			// 4 - Change the target
+			{
+				Config: testAccShortcutResourceConfig("shortcut_renamed", "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("gdrive_shortcut.shortcut", "target_id", "gdrive_file.second", "file_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccShortcutResourceConfig(name, target string) string {
+	parent := "gdrive_drive.drive.drive_id"
+	if name != "shortcut" {
+		parent = "gdrive_file.second.file_id"
+	}
+	return fmt.Sprintf(`
+resource "gdrive_drive" "drive" {
+  name                    = "shortcut test"
+  use_domain_admin_access = true
+}
+
+resource "gdrive_file" "first" {
+  name      = "first"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_file" "second" {
+  name      = "second"
+  mime_type = "application/vnd.google-apps.folder"
+  parent    = gdrive_drive.drive.drive_id
+  drive_id  = gdrive_drive.drive.drive_id
+}
+
+resource "gdrive_shortcut" "shortcut" {
+  name      = "%s"
+  parent    = %s
+  target_id = gdrive_file.%s.file_id
+}
+`, name, parent, target)
+}